@@ -1,11 +1,10 @@
 module github.com/alxmsl/prmtvs
 
-go 1.21
+go 1.23
 
 require (
 	golang.org/x/sync v0.10.0
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f
-	gopkg.in/eapache/queue.v1 v1.1.0
 )
 
 require (
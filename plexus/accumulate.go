@@ -0,0 +1,54 @@
+package plexus
+
+import "sync"
+
+// accumulator struct represents a running state of a Plexus. Merged values of all rounds are merged into the state.
+type accumulator struct {
+	lock  sync.Mutex
+	state Mergeable
+}
+
+// add merges a value of a round into the state.
+func (a *accumulator) add(v any) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.state == nil {
+		a.state = v.(Mergeable)
+	} else {
+		a.state = a.state.Merge(v.(Mergeable))
+	}
+}
+
+// complete records a result of a completed round with a given number in a journal and in a running state.
+func (plx *Plexus) complete(round uint64, result any) {
+	plx.journal.round(round, result)
+	plx.accumulator.add(result)
+}
+
+// Snapshot returns a running state of the Plexus: merged values of all rounds since the Plexus is created or reset.
+// It returns nil if there are no rounds. It panics with ErrorNotAccumulating if the Plexus is created without
+// WithAccumulator option.
+func (plx *Plexus) Snapshot() Mergeable {
+	if plx.accumulator == nil {
+		panic(ErrorNotAccumulating)
+	}
+	plx.accumulator.lock.Lock()
+	defer plx.accumulator.lock.Unlock()
+	return plx.accumulator.state
+}
+
+// Reset clears a running state of the Plexus and returns the previous state. It panics with ErrorNotAccumulating if
+// the Plexus is created without WithAccumulator option.
+func (plx *Plexus) Reset() Mergeable {
+	if plx.accumulator == nil {
+		panic(ErrorNotAccumulating)
+	}
+	plx.accumulator.lock.Lock()
+	defer plx.accumulator.lock.Unlock()
+	var state = plx.accumulator.state
+	plx.accumulator.state = nil
+	return state
+}
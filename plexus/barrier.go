@@ -0,0 +1,84 @@
+package plexus
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// barrierName is a name of a plexus of a Barrier.
+	barrierName = "barrier"
+	// phaserName is a name of a plexus of a Phaser.
+	phaserName = "phaser"
+	// tripper is a name of a sender which releases parties of a Barrier or a Phaser.
+	tripper = "tripper"
+)
+
+// trip struct represents a release of parties of a Barrier or a Phaser with a number of the completed phase.
+type trip struct {
+	phase uint64
+	once  sync.Once
+}
+
+// tripLoop sends trips into a given Plexus till the Plexus is closed. The sender is always waiting in a queue, so
+// a round is completed when all required parties arrive.
+func tripLoop(plx *Plexus) {
+	for phase := uint64(0); ; phase += 1 {
+		if plx.trySend(tripper, &trip{phase: phase}) != nil {
+			return
+		}
+	}
+}
+
+// Barrier struct represents a reusable cyclic barrier for a fixed number of parties. It is built on a Plexus, where
+// parties are receivers and a round is a trip of the barrier.
+type Barrier struct {
+	plx    *Plexus
+	action func()
+}
+
+// NewBarrier creates a Barrier for a given number of parties. A given action is optional. If it is defined, then
+// it is called once per trip, when all parties arrive and before any party is released. It returns an error wrapping
+// ErrorInvalidOptions if there are no parties.
+func NewBarrier(parties int, action func()) (*Barrier, error) {
+	plx, err := NewPlexusE(WithName(barrierName), WithSenders(tripper), WithReceiversNumber(parties))
+	if err != nil {
+		return nil, fmt.Errorf("can not create barrier: %w", err)
+	}
+	go tripLoop(plx)
+	return &Barrier{plx: plx, action: action}, nil
+}
+
+// Await blocks till all parties arrive, and it returns a number of the completed phase. It returns an error wrapping
+// ErrorNoFreeParticipant if all parties are waiting already, or ErrorBarrierClosed if the Barrier is closed.
+func (b *Barrier) Await() (uint64, error) {
+	l, err := b.plx.AcquireReceiver()
+	if err != nil {
+		return 0, fmt.Errorf("can not await: %w", err)
+	}
+	v, ok := l.Recv()
+	l.Release()
+	if !ok {
+		return 0, fmt.Errorf("can not await: %w", ErrorBarrierClosed)
+	}
+	var t = v.(*trip)
+	if b.action != nil {
+		t.once.Do(b.action)
+	}
+	return t.phase, nil
+}
+
+// Parties returns a number of parties of the Barrier.
+func (b *Barrier) Parties() int {
+	return b.plx.recvn
+}
+
+// Waiting returns a number of parties which are waiting for the trip.
+func (b *Barrier) Waiting() int {
+	return b.plx.Stats().WaitingReceivers
+}
+
+// Close closes the Barrier. Waiting parties are released with ErrorBarrierClosed.
+func (b *Barrier) Close() {
+	b.plx.Close()
+}
@@ -0,0 +1,69 @@
+package plexus
+
+import (
+	"context"
+	"errors"
+)
+
+// RecvChan returns a channel which delivers values received by a given receiver (by name). The Plexus.Recv loop runs
+// in a separate goroutine. The channel is closed when the Plexus is closed.
+func (plx *Plexus) RecvChan(name string) <-chan any {
+	var ch = make(chan any)
+	go func() {
+		defer close(ch)
+		for {
+			v, ok := plx.Recv(name)
+			if !ok {
+				return
+			}
+			select {
+			case ch <- v:
+			case <-plx.done:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// SendChan returns a channel which passes values to the Plexus from a given sender (by name). The Plexus.Send loop
+// runs in a separate goroutine. The loop stops when the channel is closed by the caller or when the Plexus is closed.
+// The channel is not drained after the Plexus is closed, so callers should select on Plexus.Done as well. Values
+// rejected by a rate limit are dropped, they are counted in ParticipantStats.Rejected.
+func (plx *Plexus) SendChan(name string) chan<- any {
+	var ch = make(chan any)
+	go func() {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				if err := plx.trySend(name, v); errors.Is(err, ErrorSendToClosedPlexus) {
+					return
+				}
+			case <-plx.done:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// trySend sends a value from a given sender (by name). It returns an error instead of a panic: an error wrapping
+// ErrorRateLimited if the call is rejected by a rate limit, or an error wrapping ErrorSendToClosedPlexus if the Plexus
+// is closed before or during the send operation. It panics if there is no sender with a given name.
+func (plx *Plexus) trySend(name string, value any) error {
+	p, err := plx.sendq.participant(name, categorySender)
+	if err != nil {
+		panic(err)
+	}
+	return plx.sendContext(context.Background(), p, value)
+}
+
+// isClosed returns TRUE if the Plexus is closed.
+func (plx *Plexus) isClosed() bool {
+	plx.lock.RLock()
+	defer plx.lock.RUnlock()
+	return plx.closed
+}
@@ -0,0 +1,123 @@
+package plexus
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT renders given plexuses to a Graphviz DOT graph. Each Plexus is a box node labelled with its name and mode.
+// Each sender and receiver is an ellipse node linked to the Plexus. Edges are labelled with a number of blocked calls
+// of a participant, edges of blocked participants are highlighted.
+func WriteDOT(w io.Writer, plexuses ...*Plexus) error {
+	return writeDOT(w, plexuses, nil)
+}
+
+// WriteDOT renders all registered plexuses to a Graphviz DOT graph. See WriteDOT function for details.
+func (r *Registry) WriteDOT(w io.Writer) error {
+	return WriteDOT(w, r.plexuses()...)
+}
+
+// WriteDOT renders all plexuses and links of the Graph to a Graphviz DOT graph. Each Link is a dashed edge from
+// a receiver of a source Plexus to a sender of a destination Plexus. See WriteDOT function for details.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	return writeDOT(w, g.Plexuses(), g.links)
+}
+
+// dotNodes struct represents identifiers of DOT nodes of senders and receivers of a Plexus by their names.
+type dotNodes struct {
+	senders   map[string]string
+	receivers map[string]string
+}
+
+// writeDOT renders given plexuses and links between them to a Graphviz DOT graph. Node identifiers are derived from
+// indexes of plexuses and participants, so unnamed plexuses and plexuses with the same name are rendered as separate
+// nodes. Names are used in labels only. Links of plexuses which are not rendered are skipped.
+func writeDOT(w io.Writer, plexuses []*Plexus, links []*Link) error {
+	var (
+		sb    strings.Builder
+		nodes = make(map[*Plexus]dotNodes, len(plexuses))
+	)
+	sb.WriteString("digraph plexus {\n\trankdir=LR;\n")
+	for i, plx := range plexuses {
+		nodes[plx] = plx.writeDOT(&sb, i)
+	}
+	for _, l := range links {
+		src, ok := nodes[l.src]
+		if !ok {
+			continue
+		}
+		dst, ok := nodes[l.dst]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&sb, "\t%s -> %s [style=dashed];\n", src.receivers[l.recv], dst.senders[l.send])
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeDOT renders nodes and edges of the Plexus with a given index and returns identifiers of nodes of its senders
+// and receivers. The Plexus node is identified as p<index>, its senders and receivers are identified as
+// p<index>_s<n> and p<index>_r<n>.
+func (plx *Plexus) writeDOT(sb *strings.Builder, idx int) dotNodes {
+	plx.lockSnapshot()
+	defer plx.lock.Unlock()
+
+	var (
+		id    = fmt.Sprintf("p%d", idx)
+		label = modeName(plx.State())
+		nodes = dotNodes{senders: make(map[string]string), receivers: make(map[string]string)}
+	)
+	if plx.name != "" {
+		label = plx.name + "\n" + label
+	}
+	if plx.closed {
+		label += "\nclosed"
+	}
+	fmt.Fprintf(sb, "\t%s [shape=box, label=%s];\n", id, quoteDOT(label))
+
+	var names, lengths = plx.sendq.lengths()
+	for i, name := range names {
+		var node = fmt.Sprintf("%s_s%d", id, i)
+		nodes.senders[name] = node
+		fmt.Fprintf(sb, "\t%s [shape=ellipse, label=%s];\n", node, quoteDOT(name))
+		fmt.Fprintf(sb, "\t%s -> %s [%s];\n", node, id, edgeAttrs(lengths[i]))
+	}
+	names, lengths = plx.recvq.lengths()
+	for i, name := range names {
+		var node = fmt.Sprintf("%s_r%d", id, i)
+		nodes.receivers[name] = node
+		fmt.Fprintf(sb, "\t%s [shape=ellipse, label=%s];\n", node, quoteDOT(name))
+		fmt.Fprintf(sb, "\t%s -> %s [%s];\n", id, node, edgeAttrs(lengths[i]))
+	}
+	return nodes
+}
+
+// dotEscaper escapes a string for a DOT double-quoted string of a label. A backslash and a double quote are escaped, a line
+// break is replaced with the DOT line break escape. Other characters, including non-ASCII ones, are kept as is.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteDOT returns a DOT double-quoted string of a given string.
+func quoteDOT(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// edgeAttrs returns DOT attributes of an edge for a given number of blocked calls.
+func edgeAttrs(queued int) string {
+	if queued > 0 {
+		return fmt.Sprintf("label=\"%d\", color=red, style=bold", queued)
+	}
+	return "label=\"0\""
+}
+
+// modeName returns a name of a given mode. See MsMr, MsSr, SsMr, SsSr constants.
+func modeName(mode int) string {
+	for name, m := range modes {
+		if m == mode {
+			return name
+		}
+	}
+	return "unknown"
+}
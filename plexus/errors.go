@@ -5,6 +5,9 @@ import "errors"
 var (
 	// ErrorCloseClosedPlexus defines error for a case when a closed Plexus is closed once again.
 	ErrorCloseClosedPlexus = errors.New("closed the closed plexus")
+	// ErrorNotAccumulating defines error for a case when there is a try detected to read a running state of a Plexus,
+	// which is created without WithAccumulator option.
+	ErrorNotAccumulating = errors.New("not accumulating plexus")
 	// ErrorNotSelectable defines error for a case when there is a try detected to use selectable functions on
 	// a not selectable Plexus. This is denied because it can produce a deadlock.
 	ErrorNotSelectable = errors.New("not selectable plexus")
@@ -27,3 +30,62 @@ var (
 	// ErrorQueuesIsNotDefined defines error for a case of getting queue when queue is not fulfilled.
 	ErrorQueuesIsNotDefined = errors.New("queues is not defined")
 )
+
+var (
+	// ErrorInvalidOptions defines error for a case when options of a Plexus are not consistent.
+	ErrorInvalidOptions = errors.New("invalid plexus options")
+)
+
+var (
+	// ErrorLeaseReleased defines error for a case of using a released lease of a sender or a receiver.
+	ErrorLeaseReleased = errors.New("lease is released")
+	// ErrorNoFreeParticipant defines error for a case of acquiring a lease when all names are leased already.
+	ErrorNoFreeParticipant = errors.New("no free participant")
+)
+
+var (
+	// ErrorRateLimited defines error for a case when a send operation exceeds a rate limit which fails calls.
+	ErrorRateLimited = errors.New("rate limit exceeded")
+)
+
+var (
+	// ErrorCallClosed defines error for a case of a ScatterGather call which is not completed because the
+	// ScatterGather is closed.
+	ErrorCallClosed = errors.New("call on the closed scatter-gather")
+)
+
+var (
+	// ErrorPubSubClosed defines error for a case of using a closed PubSub.
+	ErrorPubSubClosed = errors.New("closed pubsub")
+)
+
+var (
+	// ErrorLateValue defines error for a case of adding a value into a Window after all windows of the value are
+	// emitted.
+	ErrorLateValue = errors.New("late value")
+)
+
+var (
+	// ErrorBarrierClosed defines error for a case of awaiting a closed Barrier.
+	ErrorBarrierClosed = errors.New("closed barrier")
+	// ErrorPhaserTerminated defines error for a case of using a terminated Phaser.
+	ErrorPhaserTerminated = errors.New("terminated phaser")
+)
+
+var (
+	// ErrorInvalidTopology defines error for a case when a Topology description is not consistent.
+	ErrorInvalidTopology = errors.New("invalid topology")
+)
+
+var (
+	// ErrorPlexusAlreadyRegistered defines error for a case of registering a Plexus with an occupied name.
+	ErrorPlexusAlreadyRegistered = errors.New("plexus already registered")
+	// ErrorPlexusWithoutName defines error for a case of registering a Plexus without a name.
+	ErrorPlexusWithoutName = errors.New("plexus without name")
+)
+
+var (
+	// ErrorReplayMismatch defines error for a case when a replayed round delivers a value which differs from
+	// the recorded one.
+	ErrorReplayMismatch = errors.New("replayed value does not match recorded value")
+)
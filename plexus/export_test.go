@@ -0,0 +1,4 @@
+package plexus
+
+// WithSerialLock exports withSerialLock option for benchmarks.
+var WithSerialLock = withSerialLock
@@ -0,0 +1,82 @@
+package plexus
+
+import "context"
+
+// Sender struct represents a handle of a single sender of a Plexus. The handle validates a name once, when it is
+// created, and it caches a lookup of the sender queue for all following calls.
+type Sender struct {
+	plx   *Plexus
+	p     *participant
+	ready chan struct{}
+}
+
+// Sender returns a handle of a sender with a given name. It returns an error wrapping ErrorQueueDoesNotExist if there
+// is no sender with a given name.
+func (plx *Plexus) Sender(name string) (*Sender, error) {
+	p, err := plx.sendq.participant(name, categorySender)
+	if err != nil {
+		return nil, err
+	}
+	return &Sender{plx: plx, p: p, ready: plx.sendr[name]}, nil
+}
+
+// Name returns a name of the sender.
+func (s *Sender) Name() string {
+	return s.p.name
+}
+
+// Send puts value into the Plexus from the sender. See Plexus.Send for details.
+func (s *Sender) Send(value any) {
+	mustSend(s.plx.sendContext(context.Background(), s.p, value))
+}
+
+// SendContext puts value into the Plexus from the sender. See Plexus.SendContext for details.
+func (s *Sender) SendContext(ctx context.Context, value any) error {
+	return s.plx.sendContext(ctx, s.p, value)
+}
+
+// Heartbeat records a heartbeat of the sender between calls. See WithLiveness for details.
+func (s *Sender) Heartbeat() {
+	s.plx.heartbeat(s.plx.sendq, s.p)
+}
+
+// Ready returns a ready-channel of the sender for the select statement. It panics with ErrorNotSelectable if the
+// Plexus is created without WithSelectableSenders option. See Plexus.ReadySend for details.
+func (s *Sender) Ready() <-chan struct{} {
+	if !s.plx.selectableSenders {
+		panic(ErrorNotSelectable)
+	}
+	return s.ready
+}
+
+// Receiver struct represents a handle of a single receiver of a Plexus. The handle validates a name once, when it is
+// created, and it caches a lookup of the receiver queue for all following calls.
+type Receiver struct {
+	plx *Plexus
+	p   *participant
+}
+
+// Receiver returns a handle of a receiver with a given name. It returns an error wrapping ErrorQueueDoesNotExist if
+// there is no receiver with a given name.
+func (plx *Plexus) Receiver(name string) (*Receiver, error) {
+	p, err := plx.recvq.participant(name, categoryReceiver)
+	if err != nil {
+		return nil, err
+	}
+	return &Receiver{plx: plx, p: p}, nil
+}
+
+// Name returns a name of the receiver.
+func (r *Receiver) Name() string {
+	return r.p.name
+}
+
+// Recv returns value from the Plexus for the receiver. See Plexus.Recv for details.
+func (r *Receiver) Recv() (any, bool) {
+	return r.plx.recv(r.p)
+}
+
+// Heartbeat records a heartbeat of the receiver between calls. See WithLiveness for details.
+func (r *Receiver) Heartbeat() {
+	r.plx.heartbeat(r.plx.recvq, r.p)
+}
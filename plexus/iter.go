@@ -0,0 +1,47 @@
+package plexus
+
+import (
+	"iter"
+	"time"
+)
+
+// Envelope describes metadata of a value received by a receiver of a Plexus.
+type Envelope struct {
+	Receiver string    // Receiver is a name of the receiver.
+	Seq      int       // Seq is a sequential number of the value for the receiver. It starts from zero.
+	Time     time.Time // Time is a time when the value is received.
+}
+
+// All returns an iterator over values received by a given receiver (by name). The iteration is finished when
+// the Plexus is closed.
+func (plx *Plexus) All(name string) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for {
+			v, ok := plx.Recv(name)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Envelopes returns an iterator over values received by a given receiver (by name) together with their Envelope.
+// The iteration is finished when the Plexus is closed.
+func (plx *Plexus) Envelopes(name string) iter.Seq2[Envelope, any] {
+	return func(yield func(Envelope, any) bool) {
+		for seq := 0; ; seq += 1 {
+			v, ok := plx.Recv(name)
+			if !ok {
+				return
+			}
+			var env = Envelope{
+				Receiver: name,
+				Seq:      seq,
+				Time:     time.Now(),
+			}
+			if !yield(env, v) {
+				return
+			}
+		}
+	}
+}
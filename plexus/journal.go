@@ -0,0 +1,344 @@
+package plexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Codec declares encoding of values passed through a Plexus.
+type Codec interface {
+	// Encode returns a binary representation of a given value.
+	Encode(v any) ([]byte, error)
+	// Decode returns a value for a given binary representation.
+	Decode(data []byte) (any, error)
+}
+
+// JSONCodec struct implements Codec interface for values of type T using JSON encoding.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec[T]) Decode(data []byte) (any, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Round struct represents a recorded round of a Plexus.
+type Round struct {
+	Round     uint64         // Round is a sequential number of the round. It starts from 1.
+	Senders   map[string]any // Senders is a set of values by names of senders.
+	Receivers []string       // Receivers is a set of names of receivers in a sorted order.
+	Result    any            // Result is a value passed to all receivers.
+}
+
+// journalRecord struct represents a Round in a file. Values are encoded by a Codec.
+type journalRecord struct {
+	Round     uint64            `json:"round"`
+	Senders   map[string][]byte `json:"senders"`
+	Receivers []string          `json:"receivers"`
+	Result    []byte            `json:"result"`
+}
+
+// Journal struct records rounds of a Plexus: values of senders, names of receivers and results passed to them.
+// Journal is attached to a Plexus with WithJournal option.
+//
+// Journal keeps values of blocked Plexus.Send calls by senders in order of calls. When a round is started, Journal
+// records senders and receivers which take part in it, and it takes the oldest values of the senders. So rounds
+// without participants removed from the round requirement by a liveness check are recorded as they are.
+type Journal struct {
+	lock    sync.Mutex
+	codec   Codec
+	pending map[string][]any // pending is a set of values of blocked senders in order of calls by names of senders.
+	rounds  []Round          // rounds is a list of started rounds without results in an ascending order of numbers.
+	results map[uint64]any
+}
+
+// NewJournal creates a Journal object which encodes values with a given Codec.
+func NewJournal(codec Codec) *Journal {
+	return &Journal{
+		codec:   codec,
+		pending: map[string][]any{},
+		results: map[uint64]any{},
+	}
+}
+
+// send records a value of a sender call. It must be called in the lock of the sender with the enqueue of the call, so
+// values are recorded in order of waiters.
+func (j *Journal) send(name string, value any) {
+	if j == nil {
+		return
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.pending[name] = append(j.pending[name], value)
+}
+
+// start records senders and receivers of a round. Values of senders are taken from their blocked calls. It must be
+// called in the exclusive general lock of a Plexus before waiters of the round are dequeued, so numbers are recorded
+// in an ascending order and participants of the round are waiting.
+func (j *Journal) start(round uint64, sendq, recvq *queues) {
+	if j == nil {
+		return
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	var r = Round{Round: round, Senders: make(map[string]any, len(sendq.names))}
+	for _, name := range sendq.names {
+		if sendq.pm[name].q.len() > 0 {
+			var values = j.pending[name]
+			r.Senders[name] = values[0]
+			values[0] = nil
+			j.pending[name] = values[1:]
+		}
+	}
+	for _, name := range recvq.names {
+		if recvq.pm[name].q.len() > 0 {
+			r.Receivers = append(r.Receivers, name)
+		}
+	}
+	sort.Strings(r.Receivers)
+	j.rounds = append(j.rounds, r)
+}
+
+// round records a result of a completed round.
+func (j *Journal) round(round uint64, result any) {
+	if j == nil {
+		return
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.results[round] = result
+}
+
+// Rounds returns all completed rounds in a sequential order. Rounds which are completed after a still running round
+// are skipped. Numbers of rounds which are not started are skipped as well.
+func (j *Journal) Rounds() []Round {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	var result []Round
+	for _, r := range j.rounds {
+		res, ok := j.results[r.Round]
+		if !ok {
+			return result
+		}
+		result = append(result, Round{
+			Round:     r.Round,
+			Senders:   maps.Clone(r.Senders),
+			Receivers: append([]string{}, r.Receivers...),
+			Result:    res,
+		})
+	}
+	return result
+}
+
+// WriteTo writes all completed rounds into a given writer. Each round is a line of a JSON object.
+func (j *Journal) WriteTo(w io.Writer) (int64, error) {
+	var cw = &countWriter{w: w}
+	var enc = json.NewEncoder(cw)
+	for _, r := range j.Rounds() {
+		var rec = journalRecord{
+			Round:     r.Round,
+			Senders:   make(map[string][]byte, len(r.Senders)),
+			Receivers: r.Receivers,
+		}
+		for name, value := range r.Senders {
+			data, err := j.codec.Encode(value)
+			if err != nil {
+				return cw.n, fmt.Errorf("can not encode value of '%s' in round %d: %w", name, r.Round, err)
+			}
+			rec.Senders[name] = data
+		}
+		data, err := j.codec.Encode(r.Result)
+		if err != nil {
+			return cw.n, fmt.Errorf("can not encode result of round %d: %w", r.Round, err)
+		}
+		rec.Result = data
+		if err := enc.Encode(rec); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadJournal reads rounds written by Journal.WriteTo. Values are decoded with a given Codec.
+func ReadJournal(r io.Reader, codec Codec) ([]Round, error) {
+	var (
+		dec    = json.NewDecoder(r)
+		result []Round
+	)
+	for dec.More() {
+		var rec journalRecord
+		if err := dec.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("can not read journal: %w", err)
+		}
+		var round = Round{
+			Round:     rec.Round,
+			Senders:   make(map[string]any, len(rec.Senders)),
+			Receivers: rec.Receivers,
+		}
+		for name, data := range rec.Senders {
+			v, err := codec.Decode(data)
+			if err != nil {
+				return nil, fmt.Errorf("can not decode value of '%s' in round %d: %w", name, rec.Round, err)
+			}
+			round.Senders[name] = v
+		}
+		v, err := codec.Decode(rec.Result)
+		if err != nil {
+			return nil, fmt.Errorf("can not decode result of round %d: %w", rec.Round, err)
+		}
+		round.Result = v
+		result = append(result, round)
+	}
+	return result, nil
+}
+
+// Replay passes given rounds through a Plexus one by one. For each round it sends recorded values by senders and
+// checks that all receivers observe the recorded result. Senders and receivers which do not take part in a round are
+// removed from the round requirement while the round is passed, so rounds recorded with participants excluded by
+// a liveness check are replayed as they are. The round requirement is restored afterwards. Names of all rounds are
+// checked before the first round is passed, it returns an error wrapping ErrorQueueDoesNotExist if the Plexus does not
+// have a recorded sender or receiver. It returns an error wrapping ErrorSendToClosedPlexus if the Plexus is closed.
+func Replay(plx *Plexus, rounds []Round) error {
+	type observed struct {
+		name  string
+		value any
+		ok    bool
+	}
+	for _, r := range rounds {
+		for name := range r.Senders {
+			if _, err := plx.Sender(name); err != nil {
+				return fmt.Errorf("can not replay round %d: %w", r.Round, err)
+			}
+		}
+		for _, name := range r.Receivers {
+			if _, err := plx.Receiver(name); err != nil {
+				return fmt.Errorf("can not replay round %d: %w", r.Round, err)
+			}
+		}
+	}
+	defer plx.restore(plx.requirement())
+	for _, r := range rounds {
+		plx.require(r)
+		var senders = make([]string, 0, len(r.Senders))
+		for name := range r.Senders {
+			senders = append(senders, name)
+		}
+		sort.Strings(senders)
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, len(senders))
+		)
+		for _, name := range senders {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				if err := plx.trySend(name, r.Senders[name]); err != nil {
+					errs <- err
+				}
+			}(name)
+		}
+
+		var done = make(chan observed, len(r.Receivers))
+		for _, name := range r.Receivers {
+			go func(name string) {
+				v, ok := plx.Recv(name)
+				done <- observed{name: name, value: v, ok: ok}
+			}(name)
+		}
+		var err error
+		for range r.Receivers {
+			var o = <-done
+			switch {
+			case err != nil:
+			case !o.ok:
+				err = fmt.Errorf("can not replay round %d: %w", r.Round, ErrorSendToClosedPlexus)
+			case !reflect.DeepEqual(o.value, r.Result):
+				err = fmt.Errorf("round %d, receiver '%s' observes %v instead of %v: %w",
+					r.Round, o.name, o.value, r.Result, ErrorReplayMismatch)
+			}
+		}
+		wg.Wait()
+		close(errs)
+		if sendErr, ok := <-errs; ok && err == nil {
+			err = fmt.Errorf("can not replay round %d: %w", r.Round, sendErr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requirement returns participants of the Plexus which are removed from the round requirement.
+func (plx *Plexus) requirement() map[*participant]bool {
+	plx.lock.RLock()
+	defer plx.lock.RUnlock()
+	var excluded = make(map[*participant]bool)
+	for _, qm := range []*queues{plx.sendq, plx.recvq} {
+		for _, p := range qm.pm {
+			if p.excluded.Load() {
+				excluded[p] = true
+			}
+		}
+	}
+	return excluded
+}
+
+// restore returns the round requirement of the Plexus to a state returned by Plexus.requirement.
+func (plx *Plexus) restore(excluded map[*participant]bool) {
+	plx.lock.Lock()
+	defer plx.lock.Unlock()
+	for _, qm := range []*queues{plx.sendq, plx.recvq} {
+		for _, p := range qm.pm {
+			if excluded[p] {
+				qm.exclude(p, 0)
+			} else {
+				qm.include(p)
+			}
+		}
+	}
+}
+
+// require makes senders and receivers of a given round required, others are removed from the round requirement.
+func (plx *Plexus) require(r Round) {
+	plx.lock.Lock()
+	defer plx.lock.Unlock()
+	for name, p := range plx.sendq.pm {
+		if _, ok := r.Senders[name]; ok {
+			plx.sendq.include(p)
+		} else {
+			plx.sendq.exclude(p, 0)
+		}
+	}
+	for name, p := range plx.recvq.pm {
+		if slices.Contains(r.Receivers, name) {
+			plx.recvq.include(p)
+		} else {
+			plx.recvq.exclude(p, 0)
+		}
+	}
+}
+
+// countWriter struct counts bytes written into an underlying writer.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,104 @@
+package plexus
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// SenderLease struct represents a sender name leased by Plexus.AcquireSender. The name is not leased again till
+// the lease is released, so goroutines of a pool can join a Plexus without coordinating names.
+type SenderLease struct {
+	s        *Sender
+	released atomic.Bool
+}
+
+// AcquireSender leases an unused sender name in order of definition. It returns an error wrapping
+// ErrorNoFreeParticipant if all senders are leased. Leases do not prevent calls of Plexus.Send with an explicit name.
+func (plx *Plexus) AcquireSender() (*SenderLease, error) {
+	plx.lock.Lock()
+	defer plx.lock.Unlock()
+	name, ok := plx.sendq.lease()
+	if !ok {
+		return nil, fmt.Errorf("can not acquire sender: %w", ErrorNoFreeParticipant)
+	}
+	s, _ := plx.Sender(name)
+	return &SenderLease{s: s}, nil
+}
+
+// Name returns a leased name of the sender.
+func (l *SenderLease) Name() string {
+	return l.s.Name()
+}
+
+// Send puts value into the Plexus from the leased sender. It panics with ErrorLeaseReleased if the lease is released.
+func (l *SenderLease) Send(value any) {
+	if l.released.Load() {
+		panic(ErrorLeaseReleased)
+	}
+	l.s.Send(value)
+}
+
+// Heartbeat records a heartbeat of the leased sender. See Sender.Heartbeat for details.
+func (l *SenderLease) Heartbeat() {
+	l.s.Heartbeat()
+}
+
+// Release frees the leased name. It panics with ErrorLeaseReleased if the lease is released already.
+func (l *SenderLease) Release() {
+	if !l.released.CompareAndSwap(false, true) {
+		panic(ErrorLeaseReleased)
+	}
+	l.s.plx.lock.Lock()
+	defer l.s.plx.lock.Unlock()
+	l.s.plx.sendq.release(l.s.Name())
+}
+
+// ReceiverLease struct represents a receiver name leased by Plexus.AcquireReceiver. The name is not leased again till
+// the lease is released.
+type ReceiverLease struct {
+	r        *Receiver
+	released atomic.Bool
+}
+
+// AcquireReceiver leases an unused receiver name in order of definition. It returns an error wrapping
+// ErrorNoFreeParticipant if all receivers are leased. Leases do not prevent calls of Plexus.Recv with an explicit
+// name.
+func (plx *Plexus) AcquireReceiver() (*ReceiverLease, error) {
+	plx.lock.Lock()
+	defer plx.lock.Unlock()
+	name, ok := plx.recvq.lease()
+	if !ok {
+		return nil, fmt.Errorf("can not acquire receiver: %w", ErrorNoFreeParticipant)
+	}
+	r, _ := plx.Receiver(name)
+	return &ReceiverLease{r: r}, nil
+}
+
+// Name returns a leased name of the receiver.
+func (l *ReceiverLease) Name() string {
+	return l.r.Name()
+}
+
+// Recv returns value from the Plexus for the leased receiver. It panics with ErrorLeaseReleased if the lease is
+// released.
+func (l *ReceiverLease) Recv() (any, bool) {
+	if l.released.Load() {
+		panic(ErrorLeaseReleased)
+	}
+	return l.r.Recv()
+}
+
+// Heartbeat records a heartbeat of the leased receiver. See Receiver.Heartbeat for details.
+func (l *ReceiverLease) Heartbeat() {
+	l.r.Heartbeat()
+}
+
+// Release frees the leased name. It panics with ErrorLeaseReleased if the lease is released already.
+func (l *ReceiverLease) Release() {
+	if !l.released.CompareAndSwap(false, true) {
+		panic(ErrorLeaseReleased)
+	}
+	l.r.plx.lock.Lock()
+	defer l.r.plx.lock.Unlock()
+	l.r.plx.recvq.release(l.r.Name())
+}
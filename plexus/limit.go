@@ -0,0 +1,153 @@
+package plexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimit struct defines a token-bucket rate limit of Plexus.Send calls.
+type RateLimit struct {
+	Rate  float64 // Rate is a number of tokens added to the bucket per second.
+	Burst int     // Burst is a capacity of the bucket. The bucket is full at the beginning.
+	// Fail defines that a call fails with ErrorRateLimited if there is no token in the bucket. Otherwise, the call
+	// blocks till a token is available.
+	Fail bool
+}
+
+// bucket struct represents a token bucket of a RateLimit. It is safe for a concurrent use.
+type bucket struct {
+	lock   sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// newBucket creates a full bucket of a given RateLimit.
+func newBucket(limit RateLimit) *bucket {
+	return &bucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds tokens accumulated since the last refill. It must be called in the acquired lock.
+func (b *bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+		b.last = now
+	}
+	if b.tokens > float64(b.limit.Burst) {
+		b.tokens = float64(b.limit.Burst)
+	}
+}
+
+// take takes a token from the bucket at a given time. It returns a delay till the token is available. If the bucket
+// fails calls and there is no token, then take returns FALSE and the bucket is not changed.
+func (b *bucket) take(now time.Time) (time.Duration, bool) {
+	if b == nil {
+		return 0, true
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill(now)
+	if b.limit.Fail && b.tokens < 1 {
+		return 0, false
+	}
+	b.tokens -= 1
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	return time.Duration(-b.tokens / b.limit.Rate * float64(time.Second)), true
+}
+
+// cancel returns a token taken by a cancelled call into the bucket.
+func (b *bucket) cancel() {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.tokens += 1
+}
+
+// throttle waits for tokens of a given sender and of the Plexus. It returns an error wrapping ErrorRateLimited if a
+// bucket fails calls and there is no token, an error wrapping ErrorSendToClosedPlexus if the Plexus is closed during
+// the wait, or an error of a given context if the context is done during the wait. Taken tokens are returned into
+// buckets if the call fails.
+func (plx *Plexus) throttle(ctx context.Context, p *participant) error {
+	var buckets = [2]*bucket{p.limit, plx.limit}
+	for i, b := range buckets {
+		if b == nil {
+			continue
+		}
+		d, ok := b.take(time.Now())
+		if !ok {
+			for _, b := range buckets[:i] {
+				b.cancel()
+			}
+			p.m.reject()
+			return fmt.Errorf("can not send from '%s': %w", p.name, ErrorRateLimited)
+		}
+		if d == 0 {
+			continue
+		}
+		var t = time.NewTimer(d)
+		select {
+		case <-t.C:
+			p.m.throttle(d)
+		case <-plx.done:
+			t.Stop()
+			for _, b := range buckets[:i+1] {
+				b.cancel()
+			}
+			return fmt.Errorf("can not send from '%s': %w", p.name, ErrorSendToClosedPlexus)
+		case <-ctx.Done():
+			t.Stop()
+			for _, b := range buckets[:i+1] {
+				b.cancel()
+			}
+			return fmt.Errorf("can not send from '%s': %w", p.name, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// SendContext puts value into the Plexus from a given sender (by name) like Plexus.Send. It returns an error instead
+// of a panic, if the call is rate limited, if the Plexus is closed, or if a given context is done while the call waits
+// for a rate limit. The context does not cancel a call which is blocked in the round already.
+func (plx *Plexus) SendContext(ctx context.Context, name string, value any) error {
+	p, err := plx.sendq.participant(name, categorySender)
+	if err != nil {
+		return err
+	}
+	return plx.sendContext(ctx, p, value)
+}
+
+// sendContext waits for a rate limit and puts value into the Plexus from a given sender. It returns an error wrapping
+// ErrorSendToClosedPlexus if the Plexus is closed before or during the send operation. See Plexus.throttle for other
+// errors.
+func (plx *Plexus) sendContext(ctx context.Context, p *participant, value any) error {
+	if err := plx.throttle(ctx, p); err != nil {
+		return err
+	}
+	if !plx.send(p, value) {
+		return fmt.Errorf("can not send from '%s': %w", p.name, ErrorSendToClosedPlexus)
+	}
+	return nil
+}
+
+// mustSend panics with a given error of a send operation, if it is not nil. A send to the closed Plexus panics with
+// ErrorSendToClosedPlexus as is.
+func mustSend(err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrorSendToClosedPlexus):
+		panic(ErrorSendToClosedPlexus)
+	default:
+		panic(err)
+	}
+}
@@ -0,0 +1,123 @@
+package plexus
+
+import (
+	"time"
+)
+
+// Liveness struct defines a liveness check of senders and receivers of a Plexus. Each call of a participant is
+// a heartbeat. Participants heartbeat between calls by Sender.Heartbeat or Receiver.Heartbeat. A participant with
+// a blocked call is alive.
+type Liveness struct {
+	// Timeout is a maximal time between heartbeats of an alive participant. A participant is dead after the timeout.
+	Timeout time.Duration
+	// Interval is a period of checks. It is a half of Timeout by default.
+	Interval time.Duration
+	// Exclude defines that a dead participant is removed from the round requirement till the next call or heartbeat.
+	// The last required sender or receiver is not removed.
+	Exclude bool
+}
+
+// LivenessEvent struct represents a change of a liveness state of a participant.
+type LivenessEvent struct {
+	Name  string    // Name is a name of the participant.
+	Kind  string    // Kind is "sender" or "receiver".
+	Alive bool      // Alive defines that the participant is alive again. Otherwise, it is dead.
+	Time  time.Time // Time is a time of the check which detects the change.
+}
+
+// Observe adds a function which is called on each change of a liveness state of a participant. Functions are called
+// sequentially from a goroutine of liveness checks, so they should not block.
+func (plx *Plexus) Observe(fn func(LivenessEvent)) {
+	plx.lock.Lock()
+	defer plx.lock.Unlock()
+	plx.observers = append(plx.observers, fn)
+}
+
+// beat records a heartbeat of a given participant.
+func (plx *Plexus) beat(p *participant) {
+	if plx.liveness == nil {
+		return
+	}
+	p.beat(time.Now())
+}
+
+// heartbeat records a heartbeat of a given participant and returns the participant into the round requirement.
+func (plx *Plexus) heartbeat(qm *queues, p *participant) {
+	if plx.liveness == nil {
+		return
+	}
+	p.beat(time.Now())
+	if p.excluded.Load() {
+		plx.lock.Lock()
+		defer plx.lock.Unlock()
+		qm.include(p)
+	}
+}
+
+// watch checks liveness of participants periodically till the Plexus is closed.
+func (plx *Plexus) watch() {
+	var interval = plx.liveness.Interval
+	if interval == 0 {
+		interval = plx.liveness.Timeout / 2
+	}
+	var t = time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-plx.done:
+			return
+		case now := <-t.C:
+			plx.checkLiveness(now)
+		}
+	}
+}
+
+// checkLiveness marks participants which have missed heartbeats till a given time as dead, and dead participants
+// which have heartbeat since the last check as alive. If dead participants are removed from the round requirement,
+// then rounds of waiting participants are completed. Observers are notified about all changes.
+func (plx *Plexus) checkLiveness(now time.Time) {
+	plx.lock.Lock()
+	if plx.closed {
+		plx.lock.Unlock()
+		return
+	}
+	var events []LivenessEvent
+	events = plx.sendq.check(now, plx.liveness, categorySender, events)
+	events = plx.recvq.check(now, plx.liveness, categoryReceiver, events)
+	var observers = plx.observers
+	plx.lock.Unlock()
+
+	if plx.liveness.Exclude {
+		for plx.tryRound("") {
+		}
+	}
+	for _, e := range events {
+		for _, fn := range observers {
+			fn(e)
+		}
+	}
+}
+
+// check updates liveness states of participants at a given time. It appends events of changed states to a given
+// slice. It must be called in the acquired general lock.
+func (qm *queues) check(now time.Time, l *Liveness, kind string, events []LivenessEvent) []LivenessEvent {
+	for _, name := range qm.names {
+		var (
+			p     = qm.pm[name]
+			alive = p.q.len() > 0 || now.Sub(time.Unix(0, p.last.Load())) <= l.Timeout
+		)
+		switch {
+		case !alive && !p.dead.Load():
+			p.dead.Store(true)
+			if l.Exclude {
+				qm.exclude(p, 1)
+			}
+			events = append(events, LivenessEvent{Name: name, Kind: kind, Alive: false, Time: now})
+		case alive && p.dead.Load():
+			p.dead.Store(false)
+			qm.include(p)
+			events = append(events, LivenessEvent{Name: name, Kind: kind, Alive: true, Time: now})
+		}
+	}
+	return events
+}
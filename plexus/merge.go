@@ -8,20 +8,34 @@ type Mergeable interface {
 	Merge(Mergeable) Mergeable
 }
 
-// merge returns merged result for the given slice of channels. Element in each channel must implement Mergeable
+// merge returns merged result for values of the given slice of waiters. Each value must implement Mergeable
 // interface. Otherwise, function panics.
-func merge(chs []chan any, res Mergeable) Mergeable {
-	for _, ch := range chs {
+func merge(ws []waiter, res Mergeable) Mergeable {
+	for _, w := range ws {
+		v, ok := w.value.(Mergeable)
+		if !ok {
+			panic(ErrorValueIsNotMergeable)
+		}
 		if res == nil {
-			v := <-ch
-			if _, ok := v.(Mergeable); !ok {
-				panic(ErrorValueIsNotMergeable)
-			}
-			res = v.(Mergeable)
+			res = v
 		} else {
-			res = res.Merge((<-ch).(Mergeable))
+			res = res.Merge(v)
 		}
-		close(ch)
 	}
 	return res
 }
+
+// merge returns merged result for values of the given slice of waiters. The merge operation is recorded for
+// a participant with a given name.
+func (plx *Plexus) merge(name string, ws []waiter, res Mergeable) Mergeable {
+	defer plx.recorder.merge(plx.name, name, plx.recorder.now())
+	return merge(ws, res)
+}
+
+// unblock passes a given value to blocked calls of given waiters. A channel of a waiter keeps a single value, so
+// a call does not block on a waiter which has not started to wait yet.
+func unblock(ws []waiter, v any) {
+	for _, w := range ws {
+		w.ch <- v
+	}
+}
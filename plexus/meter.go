@@ -0,0 +1,123 @@
+package plexus
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// blockedBuckets defines upper bounds of histogram buckets for a blocked time of participants.
+var blockedBuckets = []time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// Bucket struct represents a histogram bucket: a number of observations which are less or equal an upper bound.
+type Bucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// ParticipantStats struct represents a snapshot of counters of a sender or a receiver of a Plexus.
+type ParticipantStats struct {
+	Name string // Name is a name of the participant.
+	Kind string // Kind is "sender" or "receiver".
+
+	Calls       uint64        // Calls is a number of Plexus.Send or Plexus.Recv calls.
+	Blocked     uint64        // Blocked is a number of calls which have been blocked.
+	BlockedTime time.Duration // BlockedTime is a total time spent in blocked calls.
+	// Throttled is a number of calls which have waited for a rate limit.
+	Throttled uint64
+	// ThrottledTime is a total time spent in waits for a rate limit.
+	ThrottledTime time.Duration
+	// Rejected is a number of calls which have failed with ErrorRateLimited.
+	Rejected uint64
+	// Dead defines that the participant has missed heartbeats. See WithLiveness.
+	Dead bool
+	// Excluded defines that the dead participant is removed from the round requirement. See WithLiveness.
+	Excluded bool
+	// Buckets is a histogram of a blocked time. Counts are cumulative, the last bucket has an infinite upper bound.
+	Buckets []Bucket
+}
+
+// meter struct represents counters of a single participant of a Plexus. It is safe for a concurrent use.
+type meter struct {
+	calls       atomic.Uint64
+	blocked     atomic.Uint64
+	blockedTime atomic.Int64
+	buckets     []atomic.Uint64 // buckets is a non-cumulative histogram. The last bucket is for an infinite bound.
+
+	throttled     atomic.Uint64
+	throttledTime atomic.Int64
+	rejected      atomic.Uint64
+}
+
+// newMeter creates a meter object.
+func newMeter() *meter {
+	return &meter{
+		buckets: make([]atomic.Uint64, len(blockedBuckets)+1),
+	}
+}
+
+// call counts a call of a participant.
+func (m *meter) call() {
+	m.calls.Add(1)
+}
+
+// block counts a blocked call of a participant with a given duration.
+func (m *meter) block(d time.Duration) {
+	m.blocked.Add(1)
+	m.blockedTime.Add(int64(d))
+	var idx = len(blockedBuckets)
+	for i, bound := range blockedBuckets {
+		if d <= bound {
+			idx = i
+			break
+		}
+	}
+	m.buckets[idx].Add(1)
+}
+
+// throttle counts a call of a participant which has waited for a rate limit with a given duration.
+func (m *meter) throttle(d time.Duration) {
+	m.throttled.Add(1)
+	m.throttledTime.Add(int64(d))
+}
+
+// reject counts a call of a participant which has failed with ErrorRateLimited.
+func (m *meter) reject() {
+	m.rejected.Add(1)
+}
+
+// stats returns a snapshot of counters for a participant with a given name and kind.
+func (m *meter) stats(name, kind string) ParticipantStats {
+	var (
+		buckets = make([]Bucket, 0, len(m.buckets))
+		count   uint64
+	)
+	for i := range m.buckets {
+		count += m.buckets[i].Load()
+		var bound = time.Duration(1<<63 - 1)
+		if i < len(blockedBuckets) {
+			bound = blockedBuckets[i]
+		}
+		buckets = append(buckets, Bucket{UpperBound: bound, Count: count})
+	}
+	return ParticipantStats{
+		Name:        name,
+		Kind:        kind,
+		Calls:       m.calls.Load(),
+		Blocked:     m.blocked.Load(),
+		BlockedTime: time.Duration(m.blockedTime.Load()),
+		Buckets:     buckets,
+
+		Throttled:     m.throttled.Load(),
+		ThrottledTime: time.Duration(m.throttledTime.Load()),
+		Rejected:      m.rejected.Load(),
+	}
+}
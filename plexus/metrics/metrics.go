@@ -0,0 +1,196 @@
+// Package metrics exposes counters of plexuses in the Prometheus text exposition format.
+// Details: https://prometheus.io/docs/instrumenting/exposition_formats/
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alxmsl/prmtvs/plexus"
+)
+
+// ContentType is a content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// ErrorDuplicatePlexus defines error for a case of writing metrics of several plexuses with the same name. Series of
+// such plexuses are not distinguishable by labels.
+var ErrorDuplicatePlexus = errors.New("duplicate plexus")
+
+// snapshot struct represents counters of a single Plexus.
+type snapshot struct {
+	stats        plexus.Stats
+	participants []plexus.ParticipantStats
+}
+
+// family struct describes a metric family of a Plexus.
+type family struct {
+	name  string
+	help  string
+	typ   string
+	value func(s plexus.Stats) float64
+}
+
+var families = []family{
+	{"plexus_rounds_total", "Number of completed rounds.", "counter",
+		func(s plexus.Stats) float64 { return float64(s.Rounds) }},
+	{"plexus_recv_calls_total", "Number of receive calls.", "counter",
+		func(s plexus.Stats) float64 { return float64(s.Recvs) }},
+	{"plexus_send_calls_total", "Number of send calls.", "counter",
+		func(s plexus.Stats) float64 { return float64(s.Sends) }},
+	{"plexus_waiting_receivers", "Number of receivers with blocked calls.", "gauge",
+		func(s plexus.Stats) float64 { return float64(s.WaitingReceivers) }},
+	{"plexus_waiting_senders", "Number of senders with blocked calls.", "gauge",
+		func(s plexus.Stats) float64 { return float64(s.WaitingSenders) }},
+	{"plexus_closed", "Whether the plexus is closed.", "gauge",
+		func(s plexus.Stats) float64 { return bool2float(s.Closed) }},
+}
+
+// participantFamily struct describes a metric family of a participant of a Plexus.
+type participantFamily struct {
+	name  string
+	help  string
+	value func(s plexus.ParticipantStats) float64
+}
+
+var participantFamilies = []participantFamily{
+	{"plexus_participant_calls_total", "Number of send or receive calls of a participant.",
+		func(s plexus.ParticipantStats) float64 { return float64(s.Calls) }},
+	{"plexus_participant_blocked_total", "Number of blocked calls of a participant.",
+		func(s plexus.ParticipantStats) float64 { return float64(s.Blocked) }},
+	{"plexus_participant_throttled_total", "Number of calls of a participant which have waited for a rate limit.",
+		func(s plexus.ParticipantStats) float64 { return float64(s.Throttled) }},
+	{"plexus_participant_rejected_total", "Number of calls of a participant which have failed by a rate limit.",
+		func(s plexus.ParticipantStats) float64 { return float64(s.Rejected) }},
+}
+
+const (
+	blockedSecondsName = "plexus_participant_blocked_seconds"
+	blockedSecondsHelp = "Time spent in blocked calls of a participant."
+)
+
+// Handler returns a http.Handler which exposes metrics of all plexuses registered in a given Registry. Names of
+// registered plexuses are unique, so the handler does not fail.
+func Handler(r *plexus.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = WriteRegistry(w, r)
+	})
+}
+
+// WriteRegistry writes metrics of all plexuses registered in a given Registry.
+func WriteRegistry(w io.Writer, r *plexus.Registry) error {
+	var plexuses = make([]*plexus.Plexus, 0, r.Len())
+	for _, name := range r.Names() {
+		if plx, ok := r.Lookup(name); ok {
+			plexuses = append(plexuses, plx)
+		}
+	}
+	return Write(w, plexuses...)
+}
+
+// Write writes metrics of given plexuses. Plexus metrics are labelled with a plexus name, participant metrics are
+// labelled with a plexus name, a participant name and a participant kind. Names of plexuses must be unique, so nothing
+// is written and an error wrapping plexus.ErrorPlexusWithoutName or ErrorDuplicatePlexus is returned otherwise.
+func Write(w io.Writer, plexuses ...*plexus.Plexus) error {
+	var (
+		snapshots = make([]snapshot, 0, len(plexuses))
+		names     = make(map[string]bool, len(plexuses))
+	)
+	for _, plx := range plexuses {
+		var name = plx.Name()
+		if name == "" {
+			return fmt.Errorf("can not write metrics: %w", plexus.ErrorPlexusWithoutName)
+		}
+		if names[name] {
+			return fmt.Errorf("can not write metrics of plexus '%s': %w", name, ErrorDuplicatePlexus)
+		}
+		names[name] = true
+		snapshots = append(snapshots, snapshot{
+			stats:        plx.Stats(),
+			participants: plx.Participants(),
+		})
+	}
+
+	var bw = bufio.NewWriter(w)
+	for _, f := range families {
+		writeHeader(bw, f.name, f.help, f.typ)
+		for _, s := range snapshots {
+			writeSample(bw, f.name, labels("plexus", s.stats.Name), f.value(s.stats))
+		}
+	}
+	for _, f := range participantFamilies {
+		writeHeader(bw, f.name, f.help, "counter")
+		for _, s := range snapshots {
+			for _, p := range s.participants {
+				writeSample(bw, f.name, participantLabels(s.stats.Name, p), f.value(p))
+			}
+		}
+	}
+	writeHeader(bw, blockedSecondsName, blockedSecondsHelp, "histogram")
+	for _, s := range snapshots {
+		for _, p := range s.participants {
+			var lbs = participantLabels(s.stats.Name, p)
+			for i, b := range p.Buckets {
+				var le = "+Inf"
+				if i < len(p.Buckets)-1 {
+					le = formatFloat(b.UpperBound.Seconds())
+				}
+				writeSample(bw, blockedSecondsName+"_bucket", lbs+","+labels("le", le), float64(b.Count))
+			}
+			writeSample(bw, blockedSecondsName+"_sum", lbs, p.BlockedTime.Seconds())
+			writeSample(bw, blockedSecondsName+"_count", lbs, float64(p.Blocked))
+		}
+	}
+	return bw.Flush()
+}
+
+// writeHeader writes HELP and TYPE lines of a metric family with a given name.
+func writeHeader(w *bufio.Writer, name, help, typ string) {
+	w.WriteString("# HELP " + name + " " + help + "\n")
+	w.WriteString("# TYPE " + name + " " + typ + "\n")
+}
+
+// writeSample writes a sample of a metric with a given name, labels in the exposition format and a value.
+func writeSample(w *bufio.Writer, name, labels string, value float64) {
+	w.WriteString(name + "{" + labels + "} " + formatFloat(value) + "\n")
+}
+
+// labels returns pairs of labels in the exposition format. Arguments are names and values in a sequential order.
+func labels(pairs ...string) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(pairs[i] + `="` + escape(pairs[i+1]) + `"`)
+	}
+	return sb.String()
+}
+
+// participantLabels returns labels of a participant of a Plexus with a given name.
+func participantLabels(plexusName string, p plexus.ParticipantStats) string {
+	return labels("plexus", plexusName, "participant", p.Name, "kind", p.Kind)
+}
+
+// escape escapes a label value: backslash, double-quote and line feed characters.
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat formats a value of a sample in the shortest representation.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// bool2float returns 1 for TRUE and 0 for FALSE.
+func bool2float(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
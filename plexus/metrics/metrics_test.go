@@ -0,0 +1,88 @@
+package metrics_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alxmsl/prmtvs/plexus"
+	"github.com/alxmsl/prmtvs/plexus/metrics"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type MetricsSuite struct{}
+
+var (
+	_ = Suite(&MetricsSuite{})
+)
+
+// TestWrite checks metrics of a plexus after a single round.
+func (s *MetricsSuite) TestWrite(c *C) {
+	var plx = plexus.NewPlexus(plexus.WithName(`a"b`), plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1))
+	var done = make(chan struct{})
+	go func() {
+		plx.Send("sender_0", 1)
+		close(done)
+	}()
+	plx.Recv("receiver_0")
+	<-done
+
+	var sb strings.Builder
+	c.Assert(metrics.Write(&sb, plx), IsNil)
+	var out = sb.String()
+	for _, line := range []string{
+		"# TYPE plexus_rounds_total counter",
+		`plexus_rounds_total{plexus="a\"b"} 1`,
+		`plexus_recv_calls_total{plexus="a\"b"} 1`,
+		`plexus_send_calls_total{plexus="a\"b"} 1`,
+		`plexus_closed{plexus="a\"b"} 0`,
+		`plexus_participant_calls_total{plexus="a\"b",participant="sender_0",kind="sender"} 1`,
+		`plexus_participant_calls_total{plexus="a\"b",participant="receiver_0",kind="receiver"} 1`,
+		"# TYPE plexus_participant_blocked_seconds histogram",
+		`plexus_participant_blocked_seconds_bucket{plexus="a\"b",participant="sender_0",kind="sender",le="1e-06"}`,
+		`plexus_participant_blocked_seconds_bucket{plexus="a\"b",participant="sender_0",kind="sender",le="+Inf"}`,
+	} {
+		c.Assert(strings.Contains(out, line), Equals, true, Commentf("%s", line))
+	}
+	// One of participants is blocked in a round.
+	c.Assert(strings.Count(out, "plexus_participant_blocked_seconds_count{"), Equals, 2)
+	c.Assert(strings.Count(out, `le="+Inf"} 1`+"\n"), Equals, 1)
+	c.Assert(strings.Count(out, `le="+Inf"} 0`+"\n"), Equals, 1)
+}
+
+// TestHandler checks metrics of registered plexuses are exposed via HTTP.
+func (s *MetricsSuite) TestHandler(c *C) {
+	var r = plexus.NewRegistry()
+	c.Assert(r.Register(plexus.NewPlexus(plexus.WithName("b"), plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1))), IsNil)
+	c.Assert(r.Register(plexus.NewPlexus(plexus.WithName("a"), plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1))), IsNil)
+
+	var rec = httptest.NewRecorder()
+	metrics.Handler(r).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	c.Assert(rec.Header().Get("Content-Type"), Equals, metrics.ContentType)
+
+	var out = rec.Body.String()
+	var a, b = strings.Index(out, `plexus_rounds_total{plexus="a"} 0`), strings.Index(out, `plexus_rounds_total{plexus="b"} 0`)
+	c.Assert(a >= 0 && b > a, Equals, true)
+	c.Assert(strings.Count(out, "# TYPE plexus_rounds_total"), Equals, 1)
+}
+
+// TestWriteNames checks that metrics of plexuses without names or with duplicate names are not written.
+func (s *MetricsSuite) TestWriteNames(c *C) {
+	var (
+		a       = plexus.NewPlexus(plexus.WithName("a"), plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1))
+		unnamed = plexus.NewPlexus(plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1))
+		sb      strings.Builder
+	)
+	var err = metrics.Write(&sb, a, unnamed)
+	c.Assert(errors.Is(err, plexus.ErrorPlexusWithoutName), Equals, true)
+	err = metrics.Write(&sb, a, a)
+	c.Assert(errors.Is(err, metrics.ErrorDuplicatePlexus), Equals, true)
+	c.Assert(err, ErrorMatches, "can not write metrics of plexus 'a': duplicate plexus")
+	c.Assert(sb.Len(), Equals, 0)
+}
@@ -1,33 +1,147 @@
 package plexus
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 )
 
-// Option represents an abstract option with is allowed to be set for a Plexus.
-type Option func(*Plexus)
+// Option represents an abstract option with is allowed to be set for a Plexus. Options collect a configuration of
+// a Plexus, so they are allowed to be passed in any order. The configuration is validated after all options are
+// applied.
+type Option func(*config)
+
+// config struct represents a configuration of a Plexus collected from options.
+type config struct {
+	accumulate        bool
+	journal           *Journal
+	limit             *RateLimit
+	liveness          *Liveness
+	name              string
+	receivers         []string
+	receiversNumber   int // receiversNumber is a number of receivers. It keeps a negative number to report it.
+	recorder          *Recorder
+	selectableSenders bool
+	senders           []string
+	sendersNumber     int // sendersNumber is a number of senders. It keeps a negative number to report it.
+	senderLimits      map[string]RateLimit
+	serialLock        bool
+}
+
+// validate checks a configuration is consistent. It reports plexuses without senders or receivers, negative numbers of
+// senders or receivers, empty and duplicate names, names which are used for a sender and a receiver both, invalid rate
+// limits and liveness. All found errors are joined together.
+func (cfg *config) validate() error {
+	var (
+		errs    []error
+		invalid = func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrorInvalidOptions, fmt.Sprintf(format, args...)))
+		}
+		senders   = make(map[string]struct{}, len(cfg.senders))
+		receivers = make(map[string]struct{}, len(cfg.receivers))
+	)
+	switch {
+	case cfg.sendersNumber < 0:
+		invalid("negative number of senders %d", cfg.sendersNumber)
+	case len(cfg.senders) == 0:
+		invalid("no senders")
+	}
+	switch {
+	case cfg.receiversNumber < 0:
+		invalid("negative number of receivers %d", cfg.receiversNumber)
+	case len(cfg.receivers) == 0:
+		invalid("no receivers")
+	}
+	for _, name := range cfg.senders {
+		if name == "" {
+			invalid("sender without a name")
+			continue
+		}
+		if _, ok := senders[name]; ok {
+			invalid("duplicate sender '%s'", name)
+		}
+		senders[name] = struct{}{}
+	}
+	for _, name := range cfg.receivers {
+		if name == "" {
+			invalid("receiver without a name")
+			continue
+		}
+		if _, ok := receivers[name]; ok {
+			invalid("duplicate receiver '%s'", name)
+		}
+		receivers[name] = struct{}{}
+		if _, ok := senders[name]; ok {
+			invalid("name '%s' is used for a sender and a receiver", name)
+		}
+	}
+	var validLimit = func(limit RateLimit) bool {
+		return limit.Rate > 0 && limit.Burst > 0
+	}
+	if cfg.limit != nil && !validLimit(*cfg.limit) {
+		invalid("invalid rate limit %+v", *cfg.limit)
+	}
+	var limited = make([]string, 0, len(cfg.senderLimits))
+	for name := range cfg.senderLimits {
+		limited = append(limited, name)
+	}
+	sort.Strings(limited)
+	for _, name := range limited {
+		if _, ok := senders[name]; !ok {
+			invalid("rate limit of unknown sender '%s'", name)
+		}
+		if limit := cfg.senderLimits[name]; !validLimit(limit) {
+			invalid("invalid rate limit %+v of sender '%s'", limit, name)
+		}
+	}
+	if cfg.liveness != nil && (cfg.liveness.Timeout <= 0 || cfg.liveness.Interval < 0) {
+		invalid("invalid liveness %+v", *cfg.liveness)
+	}
+	return errors.Join(errs...)
+}
+
+// WithAccumulator enables a running state of a Plexus. A merged value of each round is merged into the state, which
+// is read by Plexus.Snapshot and cleared by Plexus.Reset. All values must implement the Mergeable interface.
+func WithAccumulator() Option {
+	return func(cfg *config) {
+		cfg.accumulate = true
+	}
+}
+
+// WithJournal defines a Journal which records rounds of a Plexus. A Journal records a single Plexus.
+func WithJournal(j *Journal) Option {
+	return func(cfg *config) {
+		cfg.journal = j
+	}
+}
+
+// WithLiveness defines a liveness check of senders and receivers of a Plexus. Checks run in a separate goroutine till
+// the Plexus is closed.
+func WithLiveness(l Liveness) Option {
+	return func(cfg *config) {
+		cfg.liveness = &l
+	}
+}
 
 // WithName defines a name for a Plexus.
 func WithName(name string) Option {
-	return func(plx *Plexus) {
-		plx.lock.Lock()
-		defer plx.lock.Unlock()
+	return func(cfg *config) {
+		cfg.name = name
+	}
+}
 
-		plx.name = name
+// WithRateLimit defines a rate limit of all senders of a Plexus. Each Plexus.Send call takes a token of the limit.
+func WithRateLimit(limit RateLimit) Option {
+	return func(cfg *config) {
+		cfg.limit = &limit
 	}
 }
 
 // WithReceivers defines a set of names for receivers of a Plexus.
 func WithReceivers(names ...string) Option {
-	return func(plx *Plexus) {
-		plx.lock.Lock()
-		defer plx.lock.Unlock()
-
-		plx.recvn = len(names)
-		plx.recvq = newQueues(plx.recvn)
-		for _, name := range names {
-			plx.recvq.add(name)
-		}
+	return func(cfg *config) {
+		cfg.receivers = names
+		cfg.receiversNumber = len(names)
 	}
 }
 
@@ -35,41 +149,58 @@ func WithReceivers(names ...string) Option {
 // Receiver name is assigned automatically in a sequential order with a prefix `receiver_`.
 // E.g. `receiver_0`, `receiver_1` etc.
 func WithReceiversNumber(n int) Option {
-	return func(plx *Plexus) {
+	return func(cfg *config) {
+		if n < 0 {
+			cfg.receivers, cfg.receiversNumber = nil, n
+			return
+		}
 		var names = make([]string, 0, n)
 		for i := 0; i < n; i += 1 {
 			names = append(names, fmt.Sprintf("receiver_%d", i))
 		}
-		WithReceivers(names...)(plx)
+		WithReceivers(names...)(cfg)
+	}
+}
+
+// WithRecorder defines a Recorder which collects trace events of a Plexus.
+func WithRecorder(rec *Recorder) Option {
+	return func(cfg *config) {
+		cfg.recorder = rec
 	}
 }
 
 // WithSelectableSenders enabled a selectable senders functionality for a Plexus.
 func WithSelectableSenders() Option {
-	return func(plx *Plexus) {
-		plx.lock.Lock()
-		defer plx.lock.Unlock()
-
-		plx.selectableSenders = true
-		plx.sendr = newDoneMap(plx.sendn)
-		for name := range plx.sendq.qm {
-			plx.sendr.add(name)
+	return func(cfg *config) {
+		cfg.selectableSenders = true
+	}
+}
+
+// WithSenderRateLimit defines a rate limit of a sender with a given name. The limit is applied in addition to
+// a limit defined by WithRateLimit.
+func WithSenderRateLimit(name string, limit RateLimit) Option {
+	return func(cfg *config) {
+		if cfg.senderLimits == nil {
+			cfg.senderLimits = make(map[string]RateLimit)
 		}
+		cfg.senderLimits[name] = limit
+	}
+}
+
+// withSerialLock makes calls of a Plexus take the general lock in the exclusive mode, like a round does. So calls of
+// all participants are serialized. It keeps the locking of calls before queues are sharded by participants, and it is
+// used by benchmarks to compare both modes.
+func withSerialLock() Option {
+	return func(cfg *config) {
+		cfg.serialLock = true
 	}
 }
 
 // WithSenders defines a set of names for senders of a Plexus.
 func WithSenders(names ...string) Option {
-	return func(plx *Plexus) {
-		plx.lock.Lock()
-		defer plx.lock.Unlock()
-
-		// Define senders.
-		plx.sendn = len(names)
-		plx.sendq = newQueues(plx.sendn)
-		for _, name := range names {
-			plx.sendq.add(name)
-		}
+	return func(cfg *config) {
+		cfg.senders = names
+		cfg.sendersNumber = len(names)
 	}
 }
 
@@ -77,11 +208,15 @@ func WithSenders(names ...string) Option {
 // Sender name is assigned automatically in a sequential order with a prefix `sender_`.
 // E.g. `sender_0`, `sender_1` etc.
 func WithSendersNumber(n int) Option {
-	return func(plx *Plexus) {
+	return func(cfg *config) {
+		if n < 0 {
+			cfg.senders, cfg.sendersNumber = nil, n
+			return
+		}
 		var names = make([]string, 0, n)
 		for i := 0; i < n; i += 1 {
 			names = append(names, fmt.Sprintf("sender_%d", i))
 		}
-		WithSenders(names...)(plx)
+		WithSenders(names...)(cfg)
 	}
 }
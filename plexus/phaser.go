@@ -0,0 +1,137 @@
+package plexus
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Phaser struct represents a reusable barrier with a dynamic set of parties. It is built on a Plexus, where parties
+// are receivers and a round completes a phase. Unregistered receivers are removed from the round requirement.
+// The Phaser is terminated when the last registered party deregisters.
+type Phaser struct {
+	plx *Plexus
+
+	lock       sync.Mutex
+	started    bool
+	terminated bool
+}
+
+// Party struct represents a party registered in a Phaser.
+type Party struct {
+	ph    *Phaser
+	lease *ReceiverLease
+}
+
+// NewPhaser creates a Phaser for a given maximal number of simultaneously registered parties. It returns an error
+// wrapping ErrorInvalidOptions if the capacity is not positive.
+func NewPhaser(capacity int) (*Phaser, error) {
+	plx, err := NewPlexusE(WithName(phaserName), WithSenders(tripper), WithReceiversNumber(capacity))
+	if err != nil {
+		return nil, fmt.Errorf("can not create phaser: %w", err)
+	}
+	for _, p := range plx.recvq.pm {
+		plx.recvq.exclude(p, 0)
+	}
+	return &Phaser{plx: plx}, nil
+}
+
+// Register registers a new party. The party is required to complete the current phase. It returns an error wrapping
+// ErrorNoFreeParticipant if the Phaser is full, or ErrorPhaserTerminated if the Phaser is terminated.
+func (ph *Phaser) Register() (*Party, error) {
+	ph.lock.Lock()
+	defer ph.lock.Unlock()
+	if ph.terminated {
+		return nil, fmt.Errorf("can not register: %w", ErrorPhaserTerminated)
+	}
+	l, err := ph.plx.AcquireReceiver()
+	if err != nil {
+		return nil, fmt.Errorf("can not register: %w", err)
+	}
+	ph.plx.lock.Lock()
+	ph.plx.recvq.include(l.r.p)
+	ph.plx.lock.Unlock()
+	if !ph.started {
+		ph.started = true
+		go tripLoop(ph.plx)
+	}
+	return &Party{ph: ph, lease: l}, nil
+}
+
+// Phase returns a number of completed phases.
+func (ph *Phaser) Phase() uint64 {
+	return ph.plx.Stats().Rounds
+}
+
+// Parties returns a number of registered parties.
+func (ph *Phaser) Parties() int {
+	ph.plx.lock.RLock()
+	defer ph.plx.lock.RUnlock()
+	return ph.plx.recvq.required()
+}
+
+// Waiting returns a number of parties which are waiting for the advance.
+func (ph *Phaser) Waiting() int {
+	return ph.plx.Stats().WaitingReceivers
+}
+
+// Terminated returns TRUE if the Phaser is terminated.
+func (ph *Phaser) Terminated() bool {
+	ph.lock.Lock()
+	defer ph.lock.Unlock()
+	return ph.terminated
+}
+
+// Terminate terminates the Phaser. Waiting parties are released with ErrorPhaserTerminated. It is safe to call
+// Terminate several times.
+func (ph *Phaser) Terminate() {
+	ph.lock.Lock()
+	defer ph.lock.Unlock()
+	ph.terminate()
+}
+
+// terminate closes the Plexus of the Phaser. It must be called in the acquired lock of the Phaser.
+func (ph *Phaser) terminate() {
+	if ph.terminated {
+		return
+	}
+	ph.terminated = true
+	ph.plx.tryClose()
+}
+
+// ArriveAndAwaitAdvance blocks till all registered parties arrive, and it returns a number of the completed phase.
+// It returns an error wrapping ErrorPhaserTerminated if the Phaser is terminated.
+func (p *Party) ArriveAndAwaitAdvance() (uint64, error) {
+	v, ok := p.lease.Recv()
+	if !ok {
+		return 0, fmt.Errorf("can not await advance: %w", ErrorPhaserTerminated)
+	}
+	return v.(*trip).phase, nil
+}
+
+// ArriveAndDeregister arrives without a wait and deregisters the party, and it returns a number of the current phase.
+// If other registered parties are waiting, then the phase is completed. The Phaser is terminated when the last party
+// deregisters. It returns an error wrapping ErrorPhaserTerminated if the Phaser is terminated.
+func (p *Party) ArriveAndDeregister() (uint64, error) {
+	var ph = p.ph
+	ph.lock.Lock()
+	defer ph.lock.Unlock()
+	if ph.terminated {
+		return 0, fmt.Errorf("can not deregister: %w", ErrorPhaserTerminated)
+	}
+	ph.plx.lock.Lock()
+	ph.plx.recvq.exclude(p.lease.r.p, 0)
+	var (
+		required = ph.plx.recvq.required()
+		phase    = ph.plx.rounds
+	)
+	ph.plx.lock.Unlock()
+	p.lease.Release()
+
+	if required == 0 {
+		ph.terminate()
+		return phase, nil
+	}
+	for ph.plx.tryRound("") {
+	}
+	return phase, nil
+}
@@ -0,0 +1,88 @@
+package plexus
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Link struct represents a binding of a receiver of a source Plexus to a sender of a destination Plexus. Each value
+// received by the receiver is sent to the destination Plexus by the sender. Links are composed into pipelines and
+// DAGs: a Plexus with several receivers fans out to several links, a Plexus with several senders fans in from them.
+type Link struct {
+	src  *Plexus
+	recv string // recv is a name of the receiver of the source Plexus.
+	dst  *Plexus
+	send string // send is a name of the sender of the destination Plexus.
+
+	done chan struct{}
+	err  error
+}
+
+// Pipe creates a Link between a given receiver (by name) of a source Plexus and a given sender (by name) of
+// a destination Plexus, and runs it in a separate goroutine.
+// When the source Plexus is closed or the Link fails, the destination Plexus is closed as well. A round of
+// a destination Plexus requires all senders, so a fan-in Plexus is closed as soon as any of its links stops.
+// Values rejected by a rate limit of the destination sender are dropped, they are counted in
+// ParticipantStats.Rejected.
+func Pipe(src *Plexus, recv string, dst *Plexus, send string) *Link {
+	var l = &Link{
+		src:  src,
+		recv: recv,
+		dst:  dst,
+		send: send,
+		done: make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// Done returns a channel which is closed when the Link is stopped.
+func (l *Link) Done() <-chan struct{} {
+	return l.done
+}
+
+// Err returns an error which has stopped the Link. It returns nil while the Link is running or if the Link is
+// stopped because of the closed source or destination Plexus.
+func (l *Link) Err() error {
+	select {
+	case <-l.done:
+		return l.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks till the Link is stopped and returns the error which has stopped the Link.
+func (l *Link) Wait() error {
+	<-l.done
+	return l.err
+}
+
+func (l *Link) run() {
+	defer close(l.done)
+	defer l.dst.tryClose()
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); ok {
+				l.err = fmt.Errorf("can not pipe '%s' to '%s': %w", l.recv, l.send, err)
+			} else {
+				l.err = fmt.Errorf("can not pipe '%s' to '%s': %v", l.recv, l.send, r)
+			}
+		}
+	}()
+	for v := range l.src.All(l.recv) {
+		// A value rejected by a rate limit of the destination is dropped, it is counted in ParticipantStats.Rejected.
+		if err := l.dst.trySend(l.send, v); errors.Is(err, ErrorSendToClosedPlexus) {
+			return
+		}
+	}
+}
+
+// WaitLinks blocks till all given links are stopped and returns errors of all of them joined together.
+func WaitLinks(links ...*Link) error {
+	var errs = make([]error, 0, len(links))
+	for _, l := range links {
+		errs = append(errs, l.Wait())
+	}
+	return errors.Join(errs...)
+}
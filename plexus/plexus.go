@@ -2,6 +2,8 @@ package plexus
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -19,11 +21,19 @@ const (
 
 // Plexus struct represents a multiplexed channel. Multiplexed channel awaits data on all senders and passes it to all
 // receivers.
+//
+// Calls of Plexus.Send and Plexus.Recv take the general lock in a shared mode. A call adds its waiter into a queue of
+// its participant and updates occupancy counters atomically, so calls of different participants proceed in parallel.
+// The call which makes all required queues occupied completes the round in the exclusive lock: it takes a waiter from
+// each queue, and then it passes values to all of them outside the lock. Close, changes of the round requirement and
+// leases take the exclusive lock as well.
 type Plexus struct {
-	lock sync.RWMutex
+	lock   sync.RWMutex
+	serial bool // serial defines that calls take the general lock in the exclusive mode. See withSerialLock.
 
-	active bool
+	active atomic.Bool
 	closed bool
+	done   chan struct{} // done is closed on Plexus.Close to stop all helpers bound to the Plexus.
 
 	recvn int     // recvn is a number of simultaneous receivers.
 	recvq *queues // recvq is a named queues of blocked receivers.
@@ -32,30 +42,80 @@ type Plexus struct {
 	sendq *queues // sendq is a named queues of blocked senders.
 	sendr doneMap // sendr is a named set of ready-channels for the select statement on Plexus.Send operations.
 
-	name              string // name is just a name of the Plexus object.
-	selectableSenders bool   // selectableSenders defines that Plexus is used via select-statement.
+	accumulator       *accumulator          // accumulator merges results of rounds, if it is defined.
+	journal           *Journal              // journal records rounds of the Plexus, if it is defined.
+	limit             *bucket               // limit is a rate limit of all senders of the Plexus, if it is defined.
+	liveness          *Liveness             // liveness defines a liveness check of participants, if it is defined.
+	observers         []func(LivenessEvent) // observers are notified about changes of liveness states.
+	name              string                // name is just a name of the Plexus object.
+	recorder          *Recorder             // recorder collects trace events of the Plexus, if it is defined.
+	selectableSenders bool                  // selectableSenders defines that Plexus is used via select-statement.
+
+	rounds uint64        // rounds is a number of completed rounds.
+	recvs  atomic.Uint64 // recvs is a number of Plexus.Recv calls.
+	sends  atomic.Uint64 // sends is a number of Plexus.Send calls.
 }
 
-// NewPlexus creates a Plexus object with a required set of Option.
+// NewPlexus creates a Plexus object with a required set of Option. It panics if the configuration is not valid.
+// See NewPlexusE for details.
 func NewPlexus(options ...Option) *Plexus {
-	var plx = &Plexus{
-		active: false,
-		closed: false,
-	}
-	for _, opt := range options {
-		opt(plx)
+	plx, err := NewPlexusE(options...)
+	if err != nil {
+		panic(err)
 	}
-	plx.validate()
 	return plx
 }
 
-func (plx *Plexus) validate() {
-	if plx.sendq.cap != plx.sendn {
-		panic(ErrorUnknownState)
+// NewPlexusE creates a Plexus object with a required set of Option. Options are applied in any order. It returns
+// an error wrapping ErrorInvalidOptions if the configuration is not valid.
+func NewPlexusE(options ...Option) (*Plexus, error) {
+	var cfg = &config{}
+	for _, opt := range options {
+		opt(cfg)
 	}
-	if plx.selectableSenders && len(plx.sendr) != plx.sendn {
-		panic(ErrorUnknownState)
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	var plx = &Plexus{
+		serial:            cfg.serialLock,
+		closed:            false,
+		done:              make(chan struct{}),
+		recvn:             len(cfg.receivers),
+		recvq:             newQueues(len(cfg.receivers)),
+		sendn:             len(cfg.senders),
+		sendq:             newQueues(len(cfg.senders)),
+		journal:           cfg.journal,
+		liveness:          cfg.liveness,
+		name:              cfg.name,
+		recorder:          cfg.recorder,
+		selectableSenders: cfg.selectableSenders,
+	}
+	if cfg.accumulate {
+		plx.accumulator = &accumulator{}
 	}
+	if cfg.limit != nil {
+		plx.limit = newBucket(*cfg.limit)
+	}
+	for _, name := range cfg.receivers {
+		plx.recvq.add(name)
+	}
+	for _, name := range cfg.senders {
+		plx.sendq.add(name)
+		if limit, ok := cfg.senderLimits[name]; ok {
+			plx.sendq.pm[name].limit = newBucket(limit)
+		}
+	}
+	if plx.selectableSenders {
+		plx.sendr = newDoneMap(plx.sendn)
+		for _, name := range cfg.senders {
+			plx.sendr.add(name)
+		}
+	}
+	if plx.liveness != nil {
+		go plx.watch()
+	}
+	return plx, nil
 }
 
 func (plx *Plexus) Close() {
@@ -66,183 +126,251 @@ func (plx *Plexus) Close() {
 	}
 	plx.lock.RUnlock()
 
+	if !plx.tryClose() {
+		panic(ErrorCloseClosedPlexus)
+	}
+}
+
+// tryClose closes the Plexus in the acquired general lock. It returns FALSE if the Plexus is closed already.
+func (plx *Plexus) tryClose() bool {
 	plx.lock.Lock()
 	defer plx.lock.Unlock()
 	if plx.closed {
-		panic(ErrorCloseClosedPlexus)
+		return false
 	}
 
 	plx.recvq.close()
 	plx.sendq.close()
 	plx.sendr.close()
 	plx.closed = true
+	close(plx.done)
+	return true
+}
+
+// Done returns a channel which is closed when the Plexus is closed.
+func (plx *Plexus) Done() <-chan struct{} {
+	return plx.done
 }
 
 func (plx *Plexus) Recv(name string) (any, bool) {
-	if plx.closed {
-		return nil, false
+	p, err := plx.recvq.participant(name, categoryReceiver)
+	if err != nil {
+		panic(err)
 	}
-	plx.lock.Lock()
-	if plx.closed {
-		plx.lock.Unlock()
+	return plx.recv(p)
+}
+
+// recv returns value from the Plexus for a given receiver.
+func (plx *Plexus) recv(p *participant) (any, bool) {
+	if !plx.lockCall(plx.recvq, p) {
 		return nil, false
 	}
-	if !plx.active {
-		plx.active = true
+	plx.active.Store(true)
+	plx.recvs.Add(1)
+	p.m.call()
+	// Enqueue a receiver. If all required receivers and senders are waiting, then the call completes the round.
+	var (
+		ch    = getChan()
+		start = time.Now()
+	)
+	p.lock.Lock()
+	var occupied = plx.recvq.enqueue(p, ch, nil, start)
+	p.lock.Unlock()
+	var (
+		full  = occupied >= plx.recvq.required()
+		ready = full && plx.sendq.occupancy() >= plx.sendq.required()
+	)
+	plx.unlockCall()
+
+	// In case of selectable mode, release all senders, if all receivers are waiting.
+	if plx.selectableSenders && full {
+		plx.releaseReady()
 	}
-	// If there are not enough waiting receiver(s) or sender(s), then go ahead with block and enqueue the receiver.
-	if plx.recvq.occupancyExcept(name)+1 < plx.recvn || plx.sendq.occupancy() < plx.sendn {
-		// Enqueue a receiver.
-		var ch = make(chan any)
-		plx.recvq.enqueue(name, ch)
-
-		// In case of selectable mode, release all receivers, if they are waiting.
-		if plx.selectableSenders && plx.recvq.occupancy() == plx.recvn {
-			for name := range plx.sendq.qm {
-				plx.sendr[name] <- struct{}{}
-			}
-		}
+	return plx.wait(plx.recvq, p, ch, start, ready)
+}
 
-		plx.lock.Unlock()
-		// Block the execution till a sender.
-		v, ok := <-ch
-		return v, ok
+func (plx *Plexus) Send(name string, value any) {
+	mustSend(plx.trySend(name, value))
+}
+
+// send puts value into the Plexus from a given sender. It returns FALSE if the Plexus is closed before or during
+// the send operation.
+func (plx *Plexus) send(p *participant, value any) bool {
+	if !plx.lockCall(plx.sendq, p) {
+		return false
 	}
+	// Value must implement the Mergeable interface to be merged with values of other senders or to be accumulated.
+	// The value is checked before any counter is changed, so a rejected value does not take a round.
+	if _, ok := value.(Mergeable); !ok && (plx.sendn > 1 || plx.accumulator != nil) {
+		plx.unlockCall()
+		panic(ErrorValueIsNotMergeable)
+	}
+	plx.active.Store(true)
+	plx.sends.Add(1)
+	p.m.call()
+	// Enqueue a sender. If all required senders and receivers are waiting, then the call completes the round.
+	var (
+		ch    = getChan()
+		start = time.Now()
+	)
+	p.lock.Lock()
+	var occupied = plx.sendq.enqueue(p, ch, value, start)
+	plx.journal.send(p.name, value)
+	p.lock.Unlock()
+	var ready = occupied >= plx.sendq.required() && plx.recvq.occupancy() >= plx.recvq.required()
+	plx.unlockCall()
 
-	// In case of selectable mode, release all receivers.
-	if plx.selectableSenders {
-		for name := range plx.sendq.qm {
-			plx.sendr[name] <- struct{}{}
+	_, ok := plx.wait(plx.sendq, p, ch, start, ready)
+	return ok
+}
+
+// lockCall acquires the general lock for a call of a given participant. The lock is shared, unless the Plexus is
+// created with withSerialLock option. A participant which is removed from the round requirement by a liveness check
+// is returned into it in the exclusive lock first, so queues of excluded participants stay empty. The call is
+// a heartbeat of the participant. It returns FALSE without the lock, if the Plexus is closed.
+func (plx *Plexus) lockCall(qm *queues, p *participant) bool {
+	for {
+		plx.heartbeat(qm, p)
+		if plx.serial {
+			plx.lock.Lock()
+		} else {
+			plx.lock.RLock()
 		}
+		if plx.closed {
+			plx.unlockCall()
+			return false
+		}
+		if plx.liveness == nil || !p.excluded.Load() {
+			return true
+		}
+		plx.unlockCall()
 	}
+}
 
-	switch plx.State() {
-	case SsSr:
-		// Dequeue a sender.
-		var schs = plx.sendq.dequeue()
+// unlockCall releases the general lock acquired by lockCall.
+func (plx *Plexus) unlockCall() {
+	if plx.serial {
 		plx.lock.Unlock()
-		// Return value from the sender to the current receiver. Close sender.
-		v, ok := <-schs[0]
-		close(schs[0])
-		return v, ok
-	case SsMr:
-		// Dequeue a sender and receivers.
-		var schs = plx.sendq.dequeue()
-		var rchs = plx.recvq.dequeueExcept(name)
-		plx.lock.Unlock()
-		// Pass value from sender to receivers. Close sender and receivers.
-		v, ok := <-schs[0]
-		close(schs[0])
-		for _, ch := range rchs {
-			if ok {
-				ch <- v
-			}
-			close(ch)
+	} else {
+		plx.lock.RUnlock()
+	}
+}
+
+// wait blocks a call of a given participant till a round passes a value into a given channel. The call is blocked
+// since a given time. If the call has made the round ready, then it completes rounds first, and the call is not
+// counted as blocked if its own waiter is taken by them. It returns FALSE if the Plexus is closed.
+func (plx *Plexus) wait(qm *queues, p *participant, ch chan any, start time.Time, ready bool) (any, bool) {
+	if ready {
+		var completed bool
+		for plx.tryRound(p.name) {
+			completed = true
 		}
-		// Return value from the sender to the current receiver.
-		return v, ok
-	case MsSr:
-		// Dequeue senders.
-		var schs = plx.sendq.dequeue()
-		plx.lock.Unlock()
-		// Merge values from senders and return it to the current receiver.
-		var res Mergeable
-		res = merge(schs, res)
-		return res, true
-	case MsMr:
-		fallthrough
-	default:
-		// Dequeue receivers and senders.
-		var rchs = plx.recvq.dequeueExcept(name)
-		var schs = plx.sendq.dequeue()
-		plx.lock.Unlock()
-		// Merge values from senders and pass it to receivers. Close receivers.
-		var res Mergeable
-		res = merge(schs, res)
-		for _, ch := range rchs {
-			ch <- res
-			close(ch)
+		if completed {
+			select {
+			case v, ok := <-ch:
+				if ok {
+					putChan(ch)
+				}
+				return v, ok
+			default:
+			}
 		}
-		// Return the merged value to the current receiver.
-		return res, true
 	}
+	// Block the execution till a round. The channel is closed, if the Plexus is closed.
+	v, ok := <-ch
+	if ok {
+		putChan(ch)
+	}
+	p.m.block(time.Since(start))
+	plx.beat(p)
+	if qm == plx.recvq {
+		plx.recorder.wait(plx.name, categoryReceiver, p.name, start)
+	} else {
+		plx.recorder.wait(plx.name, categorySender, p.name, start)
+	}
+	return v, ok
 }
 
-func (plx *Plexus) Send(name string, value any) {
-	if plx.closed {
-		panic(ErrorSendToClosedPlexus)
-	}
+// tryRound completes a round of waiting participants, if all required senders and receivers are waiting. A given name
+// is a name of the participant which completes the round, it is empty if a round is ready without a call, when
+// participants are removed from the round requirement. It returns TRUE if the round is completed.
+func (plx *Plexus) tryRound(name string) bool {
 	plx.lock.Lock()
-	if plx.closed {
+	if !plx.ready() {
 		plx.lock.Unlock()
-		panic(ErrorSendToClosedPlexus)
-	}
-	if !plx.active {
-		plx.active = true
+		return false
 	}
 
-	// If there is not enough sender(s) or no waiting receiver(s), then go ahead with block and enqueue the sender.
-	if plx.sendq.occupancyExcept(name)+1 < plx.sendn || plx.recvq.occupancy() < plx.recvn {
-		// Enqueue a sender.
-		var ch = make(chan any)
-		plx.sendq.enqueue(name, ch)
+	plx.rounds += 1
+	var (
+		round = plx.rounds
+		buf   = getRoundBuf()
+	)
+	plx.journal.start(round, plx.sendq, plx.recvq)
+	defer putRoundBuf(buf)
+	defer plx.recorder.round(plx.name, name, round, plx.recorder.now())
+	buf.senders = plx.sendq.dequeue(buf.senders)
+	buf.receivers = plx.recvq.dequeue(buf.receivers)
+	plx.lock.Unlock()
 
-		plx.lock.Unlock()
-		// Block the execution till a receiver.
-		ch <- value
-		return
+	// Pass a value of a single sender as is, merge values of multiple senders.
+	var v any
+	if plx.sendn == 1 {
+		v = buf.senders[0].value
+	} else {
+		v = plx.merge(name, buf.senders, nil)
 	}
+	unblock(buf.senders, nil)
+	plx.complete(round, v)
+	unblock(buf.receivers, v)
+	return true
+}
 
-	switch plx.State() {
-	case SsSr:
-		// Dequeue a receiver.
-		var rchs = plx.recvq.dequeue()
-		plx.lock.Unlock()
-		// Pass value to receiver and close it.
-		rchs[0] <- value
-		close(rchs[0])
-	case SsMr:
-		// Dequeue receivers.
-		var rchs = plx.recvq.dequeue()
-		plx.lock.Unlock()
-		// Pass value to receivers and close them.
-		for _, rch := range rchs {
-			rch <- value
-			close(rch)
-		}
-	case MsSr:
-		// Value must implement the Mergeable interface to be passed.
-		if _, ok := value.(Mergeable); !ok {
-			panic(ErrorValueIsNotMergeable)
+// lockSnapshot acquires the exclusive general lock for a snapshot of the Plexus. Calls add waiters concurrently in
+// the shared lock, so queues are consistent with occupancy counters in the exclusive lock only. A call completes
+// the round it makes ready after it leaves the shared lock, so rounds which are ready are completed first. So
+// a snapshot never observes all required participants waiting.
+func (plx *Plexus) lockSnapshot() {
+	for {
+		plx.lock.Lock()
+		if !plx.ready() {
+			return
 		}
-		// Dequeue receiver and senders.
-		var rchs = plx.recvq.dequeue()
-		var schs = plx.sendq.dequeueExcept(name)
 		plx.lock.Unlock()
-		// Merge values from senders and pass it to receiver. Close receiver.
-		var res = value.(Mergeable)
-		res = merge(schs, res)
-		rchs[0] <- res
-		close(rchs[0])
-	case MsMr:
-		// Value must implement the Mergeable interface to be passed.
-		if _, ok := value.(Mergeable); !ok {
-			panic(ErrorValueIsNotMergeable)
+		for plx.tryRound("") {
 		}
-		// Dequeue senders and receivers.
-		var schs = plx.sendq.dequeueExcept(name)
-		var rchs = plx.recvq.dequeue()
-		plx.lock.Unlock()
-		// Merge values from senders and pass it to receivers. Close receivers.
-		var res = value.(Mergeable)
-		res = merge(schs, res)
-		for _, rch := range rchs {
-			rch <- res
-			close(rch)
+	}
+}
+
+// ready returns TRUE if all required senders and receivers are waiting. It must be called in the general lock.
+func (plx *Plexus) ready() bool {
+	return !plx.closed &&
+		plx.sendq.occupancy() >= plx.sendq.required() && plx.recvq.occupancy() >= plx.recvq.required()
+}
+
+// releaseReady releases ready-channels of all required senders, if all required receivers are waiting.
+func (plx *Plexus) releaseReady() {
+	plx.lock.Lock()
+	defer plx.lock.Unlock()
+	if !plx.closed && plx.recvq.occupancy() >= plx.recvq.required() {
+		plx.releaseSenders()
+	}
+}
+
+// releaseSenders unblocks ready-channels of all required senders.
+func (plx *Plexus) releaseSenders() {
+	for _, name := range plx.sendq.names {
+		if !plx.sendq.pm[name].excluded.Load() {
+			plx.sendr[name] <- struct{}{}
 		}
 	}
 }
 
+// Name returns a name of the Plexus defined by WithName option.
+func (plx *Plexus) Name() string {
+	return plx.name
+}
+
 func (plx *Plexus) ReadySend(name string) <-chan struct{} {
 	if !plx.selectableSenders {
 		panic(ErrorNotSelectable)
@@ -251,14 +379,19 @@ func (plx *Plexus) ReadySend(name string) <-chan struct{} {
 }
 
 func (plx *Plexus) State() int {
+	return stateOf(plx.sendn, plx.recvn)
+}
+
+// stateOf returns a state of a plexus with a given number of senders and receivers.
+func stateOf(sendn, recvn int) int {
 	switch {
-	case plx.sendn == 1 && plx.recvn == 1:
+	case sendn == 1 && recvn == 1:
 		return SsSr
-	case plx.sendn == 1 && plx.recvn > 1:
+	case sendn == 1 && recvn > 1:
 		return SsMr
-	case plx.sendn > 1 && plx.recvn == 1:
+	case sendn > 1 && recvn == 1:
 		return MsSr
-	case plx.sendn > 1 && plx.recvn > 1:
+	case sendn > 1 && recvn > 1:
 		return MsMr
 	default:
 		panic(ErrorUnknownState)
@@ -0,0 +1,67 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"sync"
+)
+
+type AccumulateSuite struct{}
+
+var (
+	_ = Suite(&AccumulateSuite{})
+)
+
+// TestAccumulate checks that merged values of rounds are merged into a running state, and the state is reset.
+func (s *AccumulateSuite) TestAccumulate(c *C) {
+	const rounds = 3
+	var (
+		plx = NewPlexus(WithSendersNumber(2), WithReceiversNumber(2), WithAccumulator())
+		wg  sync.WaitGroup
+	)
+	c.Assert(plx.Snapshot(), IsNil)
+	for i := 0; i < 2; i += 1 {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < rounds; j += 1 {
+				sendN(plx, i, Counter(1))
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < rounds; j += 1 {
+				v, _ := recvN(plx, i)
+				c.Check(v, Equals, Counter(2))
+			}
+		}(i)
+	}
+	wg.Wait()
+	c.Assert(plx.Snapshot(), Equals, Counter(2*rounds))
+
+	c.Assert(plx.Reset(), Equals, Counter(2*rounds))
+	c.Assert(plx.Snapshot(), IsNil)
+	go send0(plx, Counter(3))
+	go sendN(plx, 1, Counter(4))
+	go recvN(plx, 1)
+	v, _ := recv0(plx)
+	c.Assert(v, Equals, Counter(7))
+	c.Assert(plx.Snapshot(), Equals, Counter(7))
+}
+
+// TestAccumulateSsSr checks a running state of the SsSr plexus, and that values must be mergeable.
+func (s *AccumulateSuite) TestAccumulateSsSr(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1), WithAccumulator())
+	go send0(plx, Counter(5))
+	recv0(plx)
+	c.Assert(plx.Snapshot(), Equals, Counter(5))
+	c.Assert(func() { send0(plx, 1) }, PanicMatches, ErrorValueIsNotMergeable.Error())
+}
+
+// TestNotAccumulating checks that a running state is not available without WithAccumulator option.
+func (s *AccumulateSuite) TestNotAccumulating(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1))
+	c.Assert(func() { plx.Snapshot() }, PanicMatches, ErrorNotAccumulating.Error())
+	c.Assert(func() { plx.Reset() }, PanicMatches, ErrorNotAccumulating.Error())
+}
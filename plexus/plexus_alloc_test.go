@@ -0,0 +1,43 @@
+//go:build !race
+
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"testing"
+)
+
+type AllocSuite struct{}
+
+var (
+	_ = Suite(&AllocSuite{})
+)
+
+// TestRoundAllocs checks that steady-state rounds do not allocate memory in all modes.
+// The test is not built with a race detector, because the detector allocates memory on its own.
+func (s *AllocSuite) TestRoundAllocs(c *C) {
+	const rounds = 100
+	for _, test := range []struct {
+		sendn, recvn int
+	}{{1, 1}, {1, 4}, {4, 1}, {4, 4}} {
+		var (
+			senders   = names("sender", test.sendn)
+			receivers = names("receiver", test.recvn)
+			plx       = NewPlexus(WithSenders(senders...), WithReceivers(receivers...))
+		)
+		// Warm up pools and queues. Each run allocates a wait group and two objects per goroutine of participants
+		// regardless of a number of rounds, so any allocation of a round exceeds the fixed cost.
+		runRounds(plx, senders, receivers, rounds, false)
+		var allocs = testing.AllocsPerRun(10, func() {
+			runRounds(plx, senders, receivers, rounds, false)
+		})
+		var (
+			goroutines = test.sendn + test.recvn - 1
+			fixed      = float64(2*goroutines + 1)
+		)
+		c.Assert(allocs <= fixed, Equals, true, Commentf("%d senders, %d receivers: %.0f allocations for %d goroutines",
+			test.sendn, test.recvn, allocs, goroutines))
+	}
+}
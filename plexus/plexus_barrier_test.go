@@ -0,0 +1,251 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type BarrierSuite struct{}
+
+var (
+	_ = Suite(&BarrierSuite{})
+)
+
+const (
+	barrierParties = 4
+	barrierPhases  = 10
+)
+
+// TestAwait checks that all parties pass each phase of the Barrier together and that the action is called once per
+// trip before any party is released.
+func (s *BarrierSuite) TestAwait(c *C) {
+	var (
+		trips   atomic.Int64
+		arrived atomic.Int64
+		wg      sync.WaitGroup
+		phases  = make(chan uint64, barrierParties*barrierPhases)
+		errs    = make(chan error, barrierParties*barrierPhases)
+	)
+	b, err := NewBarrier(barrierParties, func() { trips.Add(1) })
+	c.Assert(err, IsNil)
+	c.Assert(b.Parties(), Equals, barrierParties)
+	for i := 0; i < barrierParties; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < barrierPhases; j += 1 {
+				arrived.Add(1)
+				phase, err := b.Await()
+				if err != nil {
+					errs <- err
+					return
+				}
+				if int64(phase+1) != trips.Load() || arrived.Load() < int64(phase+1)*barrierParties {
+					errs <- errors.New("party is released before the trip")
+				}
+				phases <- phase
+			}
+		}()
+	}
+	wg.Wait()
+	close(phases)
+	close(errs)
+	for err := range errs {
+		c.Fatal(err)
+	}
+	var counts = map[uint64]int{}
+	for phase := range phases {
+		counts[phase] += 1
+	}
+	c.Assert(counts, HasLen, barrierPhases)
+	for phase := uint64(0); phase < barrierPhases; phase += 1 {
+		c.Assert(counts[phase], Equals, barrierParties)
+	}
+	c.Assert(trips.Load(), Equals, int64(barrierPhases))
+}
+
+// TestWaiting checks that waiting parties are counted till the trip of the Barrier.
+func (s *BarrierSuite) TestWaiting(c *C) {
+	b, err := NewBarrier(1, nil)
+	c.Assert(err, IsNil)
+	phase, err := b.Await()
+	c.Assert(err, IsNil)
+	c.Assert(phase, Equals, uint64(0))
+
+	b, err = NewBarrier(2, nil)
+	c.Assert(err, IsNil)
+	var done = make(chan uint64)
+	go func() {
+		phase, _ := b.Await()
+		done <- phase
+	}()
+	for b.Waiting() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	go func() {
+		phase, _ := b.Await()
+		done <- phase
+	}()
+	<-done
+	<-done
+	c.Assert(b.Waiting(), Equals, 0)
+}
+
+// TestNewBarrierInvalid checks that a Barrier without parties is not created.
+func (s *BarrierSuite) TestNewBarrierInvalid(c *C) {
+	_, err := NewBarrier(0, nil)
+	c.Assert(errors.Is(err, ErrorInvalidOptions), Equals, true)
+}
+
+type PhaserSuite struct{}
+
+var (
+	_ = Suite(&PhaserSuite{})
+)
+
+// TestAdvance checks that registered parties advance phases together.
+func (s *PhaserSuite) TestAdvance(c *C) {
+	ph, err := NewPhaser(barrierParties)
+	c.Assert(err, IsNil)
+	c.Assert(ph.Parties(), Equals, 0)
+	var parties = make([]*Party, 0, barrierParties)
+	for i := 0; i < barrierParties; i += 1 {
+		p, err := ph.Register()
+		c.Assert(err, IsNil)
+		parties = append(parties, p)
+	}
+	c.Assert(ph.Parties(), Equals, barrierParties)
+	_, err = ph.Register()
+	c.Assert(errors.Is(err, ErrorNoFreeParticipant), Equals, true)
+
+	var (
+		wg     sync.WaitGroup
+		phases = make(chan uint64, barrierParties*barrierPhases)
+	)
+	for _, p := range parties {
+		wg.Add(1)
+		go func(p *Party) {
+			defer wg.Done()
+			for j := 0; j < barrierPhases; j += 1 {
+				phase, err := p.ArriveAndAwaitAdvance()
+				if err == nil {
+					phases <- phase
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+	close(phases)
+	var counts = map[uint64]int{}
+	for phase := range phases {
+		counts[phase] += 1
+	}
+	c.Assert(counts, HasLen, barrierPhases)
+	for phase := uint64(0); phase < barrierPhases; phase += 1 {
+		c.Assert(counts[phase], Equals, barrierParties)
+	}
+	c.Assert(ph.Phase(), Equals, uint64(barrierPhases))
+}
+
+// TestDeregister checks that a deregistered party completes a phase of waiting parties and that its place is
+// registered again.
+func (s *PhaserSuite) TestDeregister(c *C) {
+	ph, err := NewPhaser(2)
+	c.Assert(err, IsNil)
+	p0, _ := ph.Register()
+	p1, _ := ph.Register()
+
+	var done = make(chan uint64)
+	go func() {
+		phase, _ := p0.ArriveAndAwaitAdvance()
+		done <- phase
+	}()
+	for ph.Waiting() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	phase, err := p1.ArriveAndDeregister()
+	c.Assert(err, IsNil)
+	c.Assert(phase, Equals, uint64(0))
+	c.Assert(<-done, Equals, uint64(0))
+	c.Assert(ph.Parties(), Equals, 1)
+
+	phase, err = p0.ArriveAndAwaitAdvance()
+	c.Assert(err, IsNil)
+	c.Assert(phase, Equals, uint64(1))
+
+	p2, err := ph.Register()
+	c.Assert(err, IsNil)
+	c.Assert(ph.Parties(), Equals, 2)
+	go func() {
+		phase, _ := p2.ArriveAndAwaitAdvance()
+		done <- phase
+	}()
+	phase, err = p0.ArriveAndAwaitAdvance()
+	c.Assert(err, IsNil)
+	c.Assert(phase, Equals, uint64(2))
+	c.Assert(<-done, Equals, uint64(2))
+}
+
+// TestNewPhaserInvalid checks that a Phaser without a capacity is not created.
+func (s *PhaserSuite) TestNewPhaserInvalid(c *C) {
+	_, err := NewPhaser(0)
+	c.Assert(errors.Is(err, ErrorInvalidOptions), Equals, true)
+}
+
+// TestBarrierClose checks that Barrier.Close releases waiting parties with an error.
+func (s *BarrierSuite) TestBarrierClose(c *C) {
+	b, err := NewBarrier(2, nil)
+	c.Assert(err, IsNil)
+	var done = make(chan error)
+	go func() {
+		_, err := b.Await()
+		done <- err
+	}()
+	for b.Waiting() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	b.Close()
+	c.Assert(errors.Is(<-done, ErrorBarrierClosed), Equals, true)
+	_, err = b.Await()
+	c.Assert(err, NotNil)
+}
+
+// TestPhaserTerminate checks that Phaser.Terminate releases waiting parties with an error and that the Phaser is
+// terminated when the last party deregisters.
+func (s *PhaserSuite) TestPhaserTerminate(c *C) {
+	ph, err := NewPhaser(2)
+	c.Assert(err, IsNil)
+	p0, _ := ph.Register()
+	p1, _ := ph.Register()
+	var done = make(chan error)
+	go func() {
+		_, err := p0.ArriveAndAwaitAdvance()
+		done <- err
+	}()
+	for ph.Waiting() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	ph.Terminate()
+	ph.Terminate()
+	c.Assert(ph.Terminated(), Equals, true)
+	c.Assert(errors.Is(<-done, ErrorPhaserTerminated), Equals, true)
+	_, err = p1.ArriveAndDeregister()
+	c.Assert(errors.Is(err, ErrorPhaserTerminated), Equals, true)
+	_, err = ph.Register()
+	c.Assert(errors.Is(err, ErrorPhaserTerminated), Equals, true)
+
+	ph, err = NewPhaser(1)
+	c.Assert(err, IsNil)
+	p0, _ = ph.Register()
+	_, err = p0.ArriveAndAwaitAdvance()
+	c.Assert(err, IsNil)
+	phase, err := p0.ArriveAndDeregister()
+	c.Assert(err, IsNil)
+	c.Assert(phase, Equals, uint64(1))
+	c.Assert(ph.Terminated(), Equals, true)
+}
@@ -0,0 +1,180 @@
+package plexus_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/alxmsl/prmtvs/plexus"
+)
+
+// benchCases defines numbers of senders and receivers for benchmarks of all modes.
+var benchCases = []struct {
+	sendn, recvn int
+}{
+	{1, 1},
+	{1, 4}, {1, 16}, {1, 64},
+	{4, 1}, {16, 1}, {64, 1},
+	{4, 4}, {16, 16}, {64, 64},
+}
+
+// benchName returns a name of a sub-benchmark: a mode and numbers of senders and receivers. E.g. `MsMr/4x4`.
+func benchName(sendn, recvn int) string {
+	var mode string
+	switch {
+	case sendn == 1 && recvn == 1:
+		mode = "SsSr"
+	case sendn == 1:
+		mode = "SsMr"
+	case recvn == 1:
+		mode = "MsSr"
+	default:
+		mode = "MsMr"
+	}
+	return fmt.Sprintf("%s/%dx%d", mode, sendn, recvn)
+}
+
+// names returns n participant names with a given prefix. Names are prepared in advance, so benchmarks do not
+// allocate them on each call.
+func names(prefix string, n int) []string {
+	var result = make([]string, 0, n)
+	for i := 0; i < n; i += 1 {
+		result = append(result, fmt.Sprintf("%s_%d", prefix, i))
+	}
+	return result
+}
+
+// runRounds runs n rounds of a plexus with given numbers of senders and receivers. The first receiver runs in
+// the current goroutine, other participants run in their own goroutines. Senders send a Counter(1), so merged values
+// are small enough to be boxed without allocations. Senders of a selectable plexus wait for readiness before a send.
+func runRounds(plx *plexus.Plexus, senders, receivers []string, n int, selectable bool) {
+	var wg sync.WaitGroup
+	for _, name := range senders {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < n; i += 1 {
+				if selectable {
+					<-plx.ReadySend(name)
+				}
+				plx.Send(name, plexus.Counter(1))
+			}
+		}(name)
+	}
+	for _, name := range receivers[1:] {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < n; i += 1 {
+				plx.Recv(name)
+			}
+		}(name)
+	}
+	for i := 0; i < n; i += 1 {
+		plx.Recv(receivers[0])
+	}
+	wg.Wait()
+}
+
+func benchmarkRounds(b *testing.B, sendn, recvn int, selectable bool, extra ...plexus.Option) {
+	var (
+		senders   = names("sender", sendn)
+		receivers = names("receiver", recvn)
+		options   = append([]plexus.Option{plexus.WithSenders(senders...), plexus.WithReceivers(receivers...)}, extra...)
+	)
+	if selectable {
+		options = append(options, plexus.WithSelectableSenders())
+	}
+	var plx = plexus.NewPlexus(options...)
+	// Warm up pools and queues.
+	runRounds(plx, senders, receivers, 100, selectable)
+	b.ReportAllocs()
+	b.ResetTimer()
+	runRounds(plx, senders, receivers, b.N, selectable)
+}
+
+// runChannels runs n rounds of hand-written code equivalent to a plexus with given numbers of senders and receivers.
+// Each sender has an own channel. The first receiver collects values from all senders, merges them and passes the
+// result to other receivers through their own channels.
+func runChannels(sendn, recvn int, n int) {
+	var (
+		wg    sync.WaitGroup
+		sends = make([]chan any, 0, sendn)
+		recvs = make([]chan any, 0, recvn-1)
+	)
+	for i := 0; i < sendn; i += 1 {
+		var ch = make(chan any)
+		sends = append(sends, ch)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i += 1 {
+				ch <- plexus.Counter(1)
+			}
+		}()
+	}
+	for i := 1; i < recvn; i += 1 {
+		var ch = make(chan any)
+		recvs = append(recvs, ch)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i += 1 {
+				<-ch
+			}
+		}()
+	}
+	for i := 0; i < n; i += 1 {
+		var res plexus.Mergeable
+		for _, ch := range sends {
+			var v = (<-ch).(plexus.Mergeable)
+			if res == nil {
+				res = v
+				continue
+			}
+			res = res.Merge(v)
+		}
+		for _, ch := range recvs {
+			ch <- res
+		}
+	}
+	wg.Wait()
+}
+
+// BenchmarkRound measures rounds of a plexus in all modes.
+func BenchmarkRound(b *testing.B) {
+	for _, bc := range benchCases {
+		b.Run(benchName(bc.sendn, bc.recvn), func(b *testing.B) {
+			benchmarkRounds(b, bc.sendn, bc.recvn, false)
+		})
+	}
+}
+
+// BenchmarkRoundSerial measures rounds of a plexus whose calls take the exclusive general lock, so calls of all
+// participants are serialized. It is a baseline for the shared lock and sharded queues measured by BenchmarkRound.
+func BenchmarkRoundSerial(b *testing.B) {
+	for _, bc := range benchCases {
+		b.Run(benchName(bc.sendn, bc.recvn), func(b *testing.B) {
+			benchmarkRounds(b, bc.sendn, bc.recvn, false, plexus.WithSerialLock())
+		})
+	}
+}
+
+// BenchmarkRoundSelectable measures rounds of a plexus with selectable senders in all modes.
+func BenchmarkRoundSelectable(b *testing.B) {
+	for _, bc := range benchCases {
+		b.Run(benchName(bc.sendn, bc.recvn), func(b *testing.B) {
+			benchmarkRounds(b, bc.sendn, bc.recvn, true)
+		})
+	}
+}
+
+// BenchmarkChannels measures hand-written channels and sync.WaitGroup code equivalent to BenchmarkRound.
+func BenchmarkChannels(b *testing.B) {
+	for _, bc := range benchCases {
+		b.Run(benchName(bc.sendn, bc.recvn), func(b *testing.B) {
+			b.ReportAllocs()
+			runChannels(bc.sendn, bc.recvn, b.N)
+		})
+	}
+}
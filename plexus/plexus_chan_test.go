@@ -0,0 +1,83 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"fmt"
+)
+
+type ChanSuite struct{}
+
+var (
+	_ = Suite(&ChanSuite{})
+)
+
+// TestSendChanRecvChan checks a FIFO order for channel adapters of a sender-receiver pair.
+func (s *ChanSuite) TestSendChanRecvChan(c *C) {
+	const count = 100
+	var (
+		plx   = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		sendc = plx.SendChan("sender_0")
+		recvc = plx.RecvChan("receiver_0")
+	)
+	go func() {
+		for i := 0; i < count; i += 1 {
+			sendc <- i
+		}
+		close(sendc)
+	}()
+
+	for i := 0; i < count; i += 1 {
+		v, ok := <-recvc
+		c.Assert(ok, Equals, true)
+		c.Assert(v, Equals, i)
+	}
+}
+
+// TestRecvChanMsMr checks channel adapters for receivers of the MsMr plexus.
+func (s *ChanSuite) TestRecvChanMsMr(c *C) {
+	var (
+		plx   = NewPlexus(WithReceiversNumber(simultaneousReceivers), WithSendersNumber(simultaneousSenders))
+		recvc = make([]<-chan any, 0, simultaneousReceivers)
+	)
+	for i := 0; i < simultaneousReceivers; i += 1 {
+		recvc = append(recvc, plx.RecvChan(fmt.Sprintf("receiver_%d", i)))
+	}
+	for i := 0; i < simultaneousSenders; i += 1 {
+		go func(i int) {
+			sendN(plx, i, Counter(i+1))
+		}(i)
+	}
+	for _, ch := range recvc {
+		v, ok := <-ch
+		c.Assert(ok, Equals, true)
+		c.Assert(v, Equals, Counter(simultaneousSenders*(simultaneousSenders+1)/2))
+	}
+}
+
+// TestRecvChanClosedOnClose checks that a receiver channel is closed when the plexus is closed.
+func (s *ChanSuite) TestRecvChanClosedOnClose(c *C) {
+	var (
+		plx   = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		recvc = plx.RecvChan("receiver_0")
+	)
+	plx.Close()
+	_, ok := <-recvc
+	c.Assert(ok, Equals, false)
+}
+
+// TestSendChanStopsOnClose checks that a sender channel is not served after the plexus is closed.
+func (s *ChanSuite) TestSendChanStopsOnClose(c *C) {
+	var (
+		plx   = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		sendc = plx.SendChan("sender_0")
+	)
+	sendc <- testValue
+	plx.Close()
+	select {
+	case sendc <- testValue:
+		c.Fatal("sender channel is served after close")
+	case <-plx.Done():
+	}
+}
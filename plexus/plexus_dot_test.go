@@ -0,0 +1,158 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"strings"
+	"time"
+)
+
+type DOTSuite struct{}
+
+var (
+	_ = Suite(&DOTSuite{})
+)
+
+// TestWriteDOT checks a DOT graph of a plexus with a blocked sender.
+func (s *DOTSuite) TestWriteDOT(c *C) {
+	var plx = NewPlexus(WithName("a"), WithReceiversNumber(2), WithSendersNumber(1))
+	go send0(plx, testValue)
+	for plx.Stats().WaitingSenders == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var sb strings.Builder
+	c.Assert(WriteDOT(&sb, plx), IsNil)
+	c.Assert(sb.String(), Equals, `digraph plexus {
+	rankdir=LR;
+	p0 [shape=box, label="a\nSsMr"];
+	p0_s0 [shape=ellipse, label="sender_0"];
+	p0_s0 -> p0 [label="1", color=red, style=bold];
+	p0_r0 [shape=ellipse, label="receiver_0"];
+	p0 -> p0_r0 [label="0"];
+	p0_r1 [shape=ellipse, label="receiver_1"];
+	p0 -> p0_r1 [label="0"];
+}
+`)
+}
+
+// TestRegistryWriteDOT checks a DOT graph of registered plexuses.
+func (s *DOTSuite) TestRegistryWriteDOT(c *C) {
+	var r = NewRegistry()
+	c.Assert(r.Register(NewPlexus(WithName("b"), WithReceiversNumber(1), WithSendersNumber(2))), IsNil)
+	c.Assert(r.Register(NewPlexus(WithName("a"), WithReceiversNumber(1), WithSendersNumber(1))), IsNil)
+	var a, _ = r.Lookup("a")
+	a.Close()
+
+	var sb strings.Builder
+	c.Assert(r.WriteDOT(&sb), IsNil)
+	c.Assert(sb.String(), Equals, `digraph plexus {
+	rankdir=LR;
+	p0 [shape=box, label="a\nSsSr\nclosed"];
+	p0_s0 [shape=ellipse, label="sender_0"];
+	p0_s0 -> p0 [label="0"];
+	p0_r0 [shape=ellipse, label="receiver_0"];
+	p0 -> p0_r0 [label="0"];
+	p1 [shape=box, label="b\nMsSr"];
+	p1_s0 [shape=ellipse, label="sender_0"];
+	p1_s0 -> p1 [label="0"];
+	p1_s1 [shape=ellipse, label="sender_1"];
+	p1_s1 -> p1 [label="0"];
+	p1_r0 [shape=ellipse, label="receiver_0"];
+	p1 -> p1_r0 [label="0"];
+}
+`)
+}
+
+// TestWriteDOTEscaping checks that labels are DOT-escaped.
+func (s *DOTSuite) TestWriteDOTEscaping(c *C) {
+	var plx = NewPlexus(WithName(`ä "q" \`), WithReceivers(`r "x"`), WithSendersNumber(1))
+	defer plx.Close()
+
+	var sb strings.Builder
+	c.Assert(WriteDOT(&sb, plx), IsNil)
+	c.Assert(sb.String(), Equals, `digraph plexus {
+	rankdir=LR;
+	p0 [shape=box, label="ä \"q\" \\\nSsSr"];
+	p0_s0 [shape=ellipse, label="sender_0"];
+	p0_s0 -> p0 [label="0"];
+	p0_r0 [shape=ellipse, label="r \"x\""];
+	p0 -> p0_r0 [label="0"];
+}
+`)
+}
+
+// TestWriteDOTSameNames checks that unnamed plexuses and plexuses with the same name are rendered as separate nodes.
+func (s *DOTSuite) TestWriteDOTSameNames(c *C) {
+	var (
+		a = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		b = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		d = NewPlexus(WithName("d"), WithReceiversNumber(1), WithSendersNumber(1))
+		e = NewPlexus(WithName("d"), WithReceiversNumber(1), WithSendersNumber(1))
+	)
+	defer a.Close()
+	defer b.Close()
+	defer d.Close()
+	defer e.Close()
+
+	var sb strings.Builder
+	c.Assert(WriteDOT(&sb, a, b, d, e), IsNil)
+	c.Assert(sb.String(), Equals, `digraph plexus {
+	rankdir=LR;
+	p0 [shape=box, label="SsSr"];
+	p0_s0 [shape=ellipse, label="sender_0"];
+	p0_s0 -> p0 [label="0"];
+	p0_r0 [shape=ellipse, label="receiver_0"];
+	p0 -> p0_r0 [label="0"];
+	p1 [shape=box, label="SsSr"];
+	p1_s0 [shape=ellipse, label="sender_0"];
+	p1_s0 -> p1 [label="0"];
+	p1_r0 [shape=ellipse, label="receiver_0"];
+	p1 -> p1_r0 [label="0"];
+	p2 [shape=box, label="d\nSsSr"];
+	p2_s0 [shape=ellipse, label="sender_0"];
+	p2_s0 -> p2 [label="0"];
+	p2_r0 [shape=ellipse, label="receiver_0"];
+	p2 -> p2_r0 [label="0"];
+	p3 [shape=box, label="d\nSsSr"];
+	p3_s0 [shape=ellipse, label="sender_0"];
+	p3_s0 -> p3 [label="0"];
+	p3_r0 [shape=ellipse, label="receiver_0"];
+	p3 -> p3_r0 [label="0"];
+}
+`)
+}
+
+// TestGraphWriteDOT checks a DOT graph of plexuses and links of a graph.
+func (s *DOTSuite) TestGraphWriteDOT(c *C) {
+	t, err := ParseTopology(strings.NewReader(`{
+		"plexuses": [
+			{"name": "a", "senders": ["in"], "receivers": ["out"]},
+			{"name": "b", "senders": ["in"], "receivers": ["out"]}
+		],
+		"links": [{"from": {"plexus": "a", "name": "out"}, "to": {"plexus": "b", "name": "in"}}],
+		"inputs": [{"plexus": "a", "name": "in"}],
+		"outputs": [{"plexus": "b", "name": "out"}]
+	}`))
+	c.Assert(err, IsNil)
+	g, err := t.Build()
+	c.Assert(err, IsNil)
+	defer g.Wait()
+	defer g.Close()
+
+	var sb strings.Builder
+	c.Assert(g.WriteDOT(&sb), IsNil)
+	var dot = sb.String()
+	c.Assert(strings.HasPrefix(dot, `digraph plexus {
+	rankdir=LR;
+	p0 [shape=box, label="a\nSsSr"];
+	p0_s0 [shape=ellipse, label="in"];
+	p0_s0 -> p0 [label="0"];
+	p0_r0 [shape=ellipse, label="out"];
+`), Equals, true)
+	c.Assert(strings.HasSuffix(dot, `
+	p0_r0 -> p1_s0 [style=dashed];
+}
+`), Equals, true)
+}
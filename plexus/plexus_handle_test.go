@@ -0,0 +1,68 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+)
+
+type HandleSuite struct{}
+
+var (
+	_ = Suite(&HandleSuite{})
+)
+
+// TestHandles checks a delivery of values between sender and receiver handles.
+func (s *HandleSuite) TestHandles(c *C) {
+	const count = 100
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1))
+	sender, err := plx.Sender("sender_0")
+	c.Assert(err, IsNil)
+	c.Assert(sender.Name(), Equals, "sender_0")
+	receiver, err := plx.Receiver("receiver_0")
+	c.Assert(err, IsNil)
+	c.Assert(receiver.Name(), Equals, "receiver_0")
+
+	go func() {
+		for i := 0; i < count; i += 1 {
+			sender.Send(i)
+		}
+	}()
+	for i := 0; i < count; i += 1 {
+		v, ok := receiver.Recv()
+		c.Assert(ok, Equals, true)
+		c.Assert(v, Equals, i)
+	}
+}
+
+// TestUnknownName checks that handles are not created for unknown names, and that calls with unknown names panic.
+func (s *HandleSuite) TestUnknownName(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1))
+	_, err := plx.Sender("sender_1")
+	c.Assert(errors.Is(err, ErrorQueueDoesNotExist), Equals, true)
+	c.Assert(err, ErrorMatches, "can not find sender 'sender_1': queue does not exist")
+	_, err = plx.Receiver("receiver_1")
+	c.Assert(err, ErrorMatches, "can not find receiver 'receiver_1': queue does not exist")
+
+	c.Assert(func() { plx.Send("sender_1", 1) }, PanicMatches, "can not find sender 'sender_1': queue does not exist")
+	c.Assert(func() { plx.Recv("receiver_1") }, PanicMatches, "can not find receiver 'receiver_1': queue does not exist")
+}
+
+// TestReady checks a ready-channel of a sender handle.
+func (s *HandleSuite) TestReady(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1), WithSelectableSenders())
+	sender, _ := plx.Sender("sender_0")
+	receiver, _ := plx.Receiver("receiver_0")
+	go func() {
+		<-sender.Ready()
+		sender.Send(1)
+	}()
+	v, ok := receiver.Recv()
+	c.Assert(ok, Equals, true)
+	c.Assert(v, Equals, 1)
+
+	plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1))
+	sender, _ = plx.Sender("sender_0")
+	c.Assert(func() { sender.Ready() }, PanicMatches, ErrorNotSelectable.Error())
+}
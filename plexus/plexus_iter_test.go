@@ -0,0 +1,62 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+)
+
+type IterSuite struct{}
+
+var (
+	_ = Suite(&IterSuite{})
+)
+
+// TestAll checks a FIFO order of values returned by the Plexus.All iterator.
+func (s *IterSuite) TestAll(c *C) {
+	const count = 100
+	var plx = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+	go func() {
+		for i := 0; i < count; i += 1 {
+			send0(plx, i)
+		}
+	}()
+
+	var i int
+	for v := range plx.All("receiver_0") {
+		c.Assert(v, Equals, i)
+		i += 1
+		if i == count {
+			break
+		}
+	}
+	c.Assert(i, Equals, count)
+}
+
+// TestAllOnClosedPlexus checks that the Plexus.All iterator is finished on a closed plexus.
+func (s *IterSuite) TestAllOnClosedPlexus(c *C) {
+	var plx = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+	plx.Close()
+	for range plx.All("receiver_0") {
+		c.Fatal("value is received from the closed plexus")
+	}
+}
+
+// TestEnvelopes checks envelopes returned by the Plexus.Envelopes iterator.
+func (s *IterSuite) TestEnvelopes(c *C) {
+	const count = 10
+	var plx = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+	go func() {
+		for i := 0; i < count; i += 1 {
+			send0(plx, i)
+		}
+	}()
+
+	for env, v := range plx.Envelopes("receiver_0") {
+		c.Assert(env.Receiver, Equals, "receiver_0")
+		c.Assert(env.Seq, Equals, v)
+		c.Assert(env.Time.IsZero(), Equals, false)
+		if env.Seq == count-1 {
+			break
+		}
+	}
+}
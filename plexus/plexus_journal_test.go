@@ -0,0 +1,194 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+)
+
+type JournalSuite struct{}
+
+var (
+	_ = Suite(&JournalSuite{})
+)
+
+// TestRecordReplay checks that rounds recorded for the MsMr plexus are replayed on a new plexus.
+func (s *JournalSuite) TestRecordReplay(c *C) {
+	const count = 10
+	var (
+		j   = NewJournal(JSONCodec[Counter]{})
+		plx = NewPlexus(WithReceiversNumber(3), WithSendersNumber(2), WithJournal(j))
+		wg  sync.WaitGroup
+	)
+	for i := 0; i < 2; i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < count; k += 1 {
+				sendN(plx, i, Counter(k*(i+1)))
+			}
+		}(i)
+	}
+	for i := 0; i < 3; i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < count; k += 1 {
+				recvN(plx, i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var rounds = j.Rounds()
+	c.Assert(rounds, HasLen, count)
+	for k, r := range rounds {
+		c.Assert(r.Round, Equals, uint64(k+1))
+		c.Assert(r.Senders, DeepEquals, map[string]any{"sender_0": Counter(k), "sender_1": Counter(2 * k)})
+		c.Assert(r.Receivers, DeepEquals, []string{"receiver_0", "receiver_1", "receiver_2"})
+		c.Assert(r.Result, Equals, Counter(3*k))
+	}
+
+	var buf bytes.Buffer
+	_, err := j.WriteTo(&buf)
+	c.Assert(err, IsNil)
+	replayed, err := ReadJournal(&buf, JSONCodec[Counter]{})
+	c.Assert(err, IsNil)
+	c.Assert(replayed, DeepEquals, rounds)
+
+	c.Assert(Replay(NewPlexus(WithReceiversNumber(3), WithSendersNumber(2)), replayed), IsNil)
+}
+
+// TestReplayMismatch checks that Replay reports a round with a different result.
+func (s *JournalSuite) TestReplayMismatch(c *C) {
+	var rounds = []Round{{
+		Round:     1,
+		Senders:   map[string]any{"sender_0": Counter(1), "sender_1": Counter(2)},
+		Receivers: []string{"receiver_0"},
+		Result:    Counter(4),
+	}}
+	var err = Replay(NewPlexus(WithReceiversNumber(1), WithSendersNumber(2)), rounds)
+	c.Assert(errors.Is(err, ErrorReplayMismatch), Equals, true)
+	c.Assert(err, ErrorMatches, "round 1, receiver 'receiver_0' observes 3 instead of 4: .*")
+}
+
+// TestNotMergeable checks that a rejected value does not take a round, so following rounds are recorded.
+func (s *JournalSuite) TestNotMergeable(c *C) {
+	var (
+		j    = NewJournal(JSONCodec[Counter]{})
+		plx  = NewPlexus(WithReceiversNumber(1), WithSendersNumber(2), WithJournal(j))
+		done = make(chan any)
+	)
+	go func() {
+		v, _ := recv0(plx)
+		done <- v
+	}()
+	go sendN(plx, 0, Counter(1))
+	for len(plx.Waiting()) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(func() { sendN(plx, 1, testValue) }, PanicMatches, ErrorValueIsNotMergeable.Error())
+	c.Assert(plx.Stats().Rounds, Equals, uint64(0))
+
+	sendN(plx, 1, Counter(2))
+	c.Assert(<-done, Equals, Counter(3))
+	c.Assert(plx.Stats().Rounds, Equals, uint64(1))
+	c.Assert(j.Rounds(), DeepEquals, []Round{{
+		Round:     1,
+		Senders:   map[string]any{"sender_0": Counter(1), "sender_1": Counter(2)},
+		Receivers: []string{"receiver_0"},
+		Result:    Counter(3),
+	}})
+}
+
+// TestReplayUnknownName checks that Replay reports names which are not defined in a plexus before any round is
+// passed.
+func (s *JournalSuite) TestReplayUnknownName(c *C) {
+	var rounds = []Round{{
+		Round:     1,
+		Senders:   map[string]any{"sender_0": Counter(1)},
+		Receivers: []string{"receiver_0"},
+		Result:    Counter(1),
+	}, {
+		Round:     2,
+		Senders:   map[string]any{"sender_0": Counter(1)},
+		Receivers: []string{"receiver_0", "receiver_1"},
+		Result:    Counter(1),
+	}}
+	var (
+		plx = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		err = Replay(plx, rounds)
+	)
+	c.Assert(errors.Is(err, ErrorQueueDoesNotExist), Equals, true)
+	c.Assert(err, ErrorMatches, "can not replay round 2: can not find receiver 'receiver_1': .*")
+	c.Assert(plx.Stats().Rounds, Equals, uint64(0))
+}
+
+// TestExclude checks that rounds without senders removed from the round requirement by a liveness check are recorded
+// with senders which take part in them.
+func (s *JournalSuite) TestExclude(c *C) {
+	var (
+		j   = NewJournal(JSONCodec[Counter]{})
+		plx = NewPlexus(WithSendersNumber(2), WithReceiversNumber(1), WithJournal(j),
+			WithLiveness(Liveness{Timeout: 20 * time.Millisecond, Interval: 5 * time.Millisecond, Exclude: true}))
+		values = make(chan any)
+	)
+	defer plx.Close()
+	go func() {
+		for {
+			v, ok := recv0(plx)
+			if !ok {
+				return
+			}
+			values <- v
+		}
+	}()
+
+	go sendN(plx, 0, Counter(1))
+	c.Assert(<-values, Equals, Counter(1))
+	sender, _ := plx.Sender("sender_1")
+	sender.Heartbeat()
+	go sendN(plx, 0, Counter(3))
+	go sendN(plx, 1, Counter(2))
+	c.Assert(<-values, Equals, Counter(5))
+
+	c.Assert(j.Rounds(), DeepEquals, []Round{{
+		Round:     1,
+		Senders:   map[string]any{"sender_0": Counter(1)},
+		Receivers: []string{"receiver_0"},
+		Result:    Counter(1),
+	}, {
+		Round:     2,
+		Senders:   map[string]any{"sender_0": Counter(3), "sender_1": Counter(2)},
+		Receivers: []string{"receiver_0"},
+		Result:    Counter(5),
+	}})
+	var buf bytes.Buffer
+	_, err := j.WriteTo(&buf)
+	c.Assert(err, IsNil)
+
+	// Rounds without excluded senders are replayed into a plexus without a liveness check.
+	var target = NewPlexus(WithSendersNumber(2), WithReceiversNumber(1))
+	defer target.Close()
+	c.Assert(Replay(target, j.Rounds()), IsNil)
+	c.Assert(target.Stats().Rounds, Equals, uint64(2))
+	c.Assert(target.Participants()[1].Excluded, Equals, false)
+}
+
+// TestReplayClosed checks that Replay into a closed plexus returns an error.
+func (s *JournalSuite) TestReplayClosed(c *C) {
+	var plx = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+	plx.Close()
+	var err = Replay(plx, []Round{{
+		Round:     1,
+		Senders:   map[string]any{"sender_0": Counter(1)},
+		Receivers: []string{"receiver_0"},
+		Result:    Counter(1),
+	}})
+	c.Assert(errors.Is(err, ErrorSendToClosedPlexus), Equals, true)
+	c.Assert(err, ErrorMatches, "can not replay round 1: .*send to the closed plexus")
+}
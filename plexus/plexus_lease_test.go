@@ -0,0 +1,87 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"sync"
+)
+
+type LeaseSuite struct{}
+
+var (
+	_ = Suite(&LeaseSuite{})
+)
+
+// TestAcquire checks that names are leased in order of definition and that a released name is leased again.
+func (s *LeaseSuite) TestAcquire(c *C) {
+	var plx = NewPlexus(WithSendersNumber(2), WithReceiversNumber(1))
+	s0, err := plx.AcquireSender()
+	c.Assert(err, IsNil)
+	c.Assert(s0.Name(), Equals, "sender_0")
+	s1, err := plx.AcquireSender()
+	c.Assert(err, IsNil)
+	c.Assert(s1.Name(), Equals, "sender_1")
+	_, err = plx.AcquireSender()
+	c.Assert(errors.Is(err, ErrorNoFreeParticipant), Equals, true)
+
+	s0.Release()
+	s2, err := plx.AcquireSender()
+	c.Assert(err, IsNil)
+	c.Assert(s2.Name(), Equals, "sender_0")
+
+	r0, err := plx.AcquireReceiver()
+	c.Assert(err, IsNil)
+	c.Assert(r0.Name(), Equals, "receiver_0")
+	_, err = plx.AcquireReceiver()
+	c.Assert(err, ErrorMatches, "can not acquire receiver: no free participant")
+}
+
+// TestReleased checks that a released lease can not be used or released once again.
+func (s *LeaseSuite) TestReleased(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1))
+	sl, _ := plx.AcquireSender()
+	rl, _ := plx.AcquireReceiver()
+	sl.Release()
+	rl.Release()
+	c.Assert(func() { sl.Send(1) }, PanicMatches, ErrorLeaseReleased.Error())
+	c.Assert(func() { sl.Release() }, PanicMatches, ErrorLeaseReleased.Error())
+	c.Assert(func() { rl.Recv() }, PanicMatches, ErrorLeaseReleased.Error())
+	c.Assert(func() { rl.Release() }, PanicMatches, ErrorLeaseReleased.Error())
+}
+
+// TestLeasePool checks that a pool of goroutines joins the MsMr plexus by leases.
+func (s *LeaseSuite) TestLeasePool(c *C) {
+	var (
+		plx = NewPlexus(WithReceiversNumber(simultaneousReceivers), WithSendersNumber(simultaneousSenders))
+		wg  sync.WaitGroup
+		res = make(chan any, simultaneousReceivers)
+	)
+	for i := 0; i < simultaneousSenders; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, err := plx.AcquireSender()
+			c.Check(err, IsNil)
+			defer l.Release()
+			l.Send(Counter(1))
+		}()
+	}
+	for i := 0; i < simultaneousReceivers; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, err := plx.AcquireReceiver()
+			c.Check(err, IsNil)
+			defer l.Release()
+			v, _ := l.Recv()
+			res <- v
+		}()
+	}
+	wg.Wait()
+	close(res)
+	for v := range res {
+		c.Assert(v, Equals, Counter(simultaneousSenders))
+	}
+}
@@ -0,0 +1,157 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"context"
+	"errors"
+	"time"
+)
+
+type LimitSuite struct{}
+
+var (
+	_ = Suite(&LimitSuite{})
+)
+
+// recvAll receives values by the first receiver till the Plexus is closed.
+func recvAll(plx *Plexus) {
+	go func() {
+		for {
+			if _, ok := recv0(plx); !ok {
+				return
+			}
+		}
+	}()
+}
+
+// TestRateLimitBlocks checks that sends of a limited sender block till tokens are available. The rate is low, so
+// a send is throttled unless a previous send takes a whole period of the rate.
+func (s *LimitSuite) TestRateLimitBlocks(c *C) {
+	const (
+		count  = 4
+		period = 50 * time.Millisecond
+	)
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1),
+		WithSenderRateLimit("sender_0", RateLimit{Rate: float64(time.Second / period), Burst: 1}))
+	defer plx.Close()
+	recvAll(plx)
+
+	var start = time.Now()
+	for i := 0; i < count; i += 1 {
+		send0(plx, i)
+	}
+	c.Assert(time.Since(start) >= (count-1)*period*9/10, Equals, true)
+	var stats = plx.Stats()
+	c.Assert(stats.Throttled >= 1 && stats.Throttled <= count-1, Equals, true)
+	c.Assert(stats.Rejected, Equals, uint64(0))
+	var sender = plx.Participants()[0]
+	c.Assert(sender.Throttled, Equals, stats.Throttled)
+	c.Assert(sender.ThrottledTime > 0, Equals, true)
+}
+
+// TestRateLimitFails checks that sends fail if a limit fails calls and there is no token.
+func (s *LimitSuite) TestRateLimitFails(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1),
+		WithRateLimit(RateLimit{Rate: 0.001, Burst: 1, Fail: true}))
+	defer plx.Close()
+	recvAll(plx)
+
+	c.Assert(plx.SendContext(context.Background(), "sender_0", 1), IsNil)
+	var err = plx.SendContext(context.Background(), "sender_0", 2)
+	c.Assert(errors.Is(err, ErrorRateLimited), Equals, true)
+	c.Assert(err, ErrorMatches, "can not send from 'sender_0': rate limit exceeded")
+	c.Assert(func() { send0(plx, 3) }, PanicMatches, "can not send from 'sender_0': rate limit exceeded")
+
+	sender, _ := plx.Sender("sender_0")
+	c.Assert(errors.Is(sender.SendContext(context.Background(), 4), ErrorRateLimited), Equals, true)
+	c.Assert(plx.Stats().Rejected, Equals, uint64(3))
+	c.Assert(plx.Participants()[0].Rejected, Equals, uint64(3))
+}
+
+// TestSendContextCancel checks that a context cancels a wait for a rate limit, and the token is returned back.
+func (s *LimitSuite) TestSendContextCancel(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1),
+		WithSenderRateLimit("sender_0", RateLimit{Rate: 20, Burst: 1}))
+	defer plx.Close()
+	recvAll(plx)
+
+	c.Assert(plx.SendContext(context.Background(), "sender_0", 1), IsNil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	var err = plx.SendContext(ctx, "sender_0", 2)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	// The cancelled call does not consume a token, so the next call waits for a single token only.
+	var start = time.Now()
+	c.Assert(plx.SendContext(context.Background(), "sender_0", 3), IsNil)
+	c.Assert(time.Since(start) < 75*time.Millisecond, Equals, true)
+}
+
+// TestRateLimitOptions checks validation of rate limit options.
+func (s *LimitSuite) TestRateLimitOptions(c *C) {
+	_, err := NewPlexusE(WithSendersNumber(1), WithReceiversNumber(1),
+		WithRateLimit(RateLimit{Rate: 0, Burst: 1}),
+		WithSenderRateLimit("sender_1", RateLimit{Rate: 1, Burst: 1}),
+		WithSenderRateLimit("sender_0", RateLimit{Rate: 1, Burst: 0}))
+	c.Assert(err, ErrorMatches, "invalid plexus options: invalid rate limit \\{Rate:0 Burst:1 Fail:false\\}\n"+
+		"invalid plexus options: invalid rate limit \\{Rate:1 Burst:0 Fail:false\\} of sender 'sender_0'\n"+
+		"invalid plexus options: rate limit of unknown sender 'sender_1'")
+}
+
+// TestRateLimitFailsInBackground checks that channel adapters and pipes drop values rejected by a rate limit instead
+// of a panic.
+func (s *LimitSuite) TestRateLimitFailsInBackground(c *C) {
+	var limit = RateLimit{Rate: 0.001, Burst: 1, Fail: true}
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1), WithRateLimit(limit))
+	var ch = plx.SendChan("sender_0")
+	go recv0(plx)
+	ch <- 1
+	ch <- 2
+	for plx.Stats().Rejected == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	plx.Close()
+
+	var (
+		src = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1))
+		dst = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1), WithRateLimit(limit))
+		l   = Pipe(src, "receiver_0", dst, "sender_0")
+	)
+	go recv0(dst)
+	send0(src, 1)
+	send0(src, 2)
+	for dst.Stats().Rejected == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(dst.Stats().Closed, Equals, false)
+	src.Close()
+	c.Assert(l.Wait(), IsNil)
+	c.Assert(dst.Stats().Closed, Equals, true)
+}
+
+// TestRateLimitClose checks that Plexus.Close releases a send which waits for a rate limit, and sends to the closed
+// Plexus return an error.
+func (s *LimitSuite) TestRateLimitClose(c *C) {
+	var plx = NewPlexus(WithSendersNumber(1), WithReceiversNumber(1),
+		WithRateLimit(RateLimit{Rate: 0.01, Burst: 1}))
+	recvAll(plx)
+	c.Assert(plx.SendContext(context.Background(), "sender_0", 1), IsNil)
+
+	var done = make(chan error)
+	go func() {
+		done <- plx.SendContext(context.Background(), "sender_0", 2)
+	}()
+	time.Sleep(time.Millisecond)
+	plx.Close()
+	var err = <-done
+	c.Assert(errors.Is(err, ErrorSendToClosedPlexus), Equals, true)
+	c.Assert(err, ErrorMatches, "can not send from 'sender_0': send to the closed plexus")
+
+	err = plx.SendContext(context.Background(), "sender_0", 3)
+	c.Assert(errors.Is(err, ErrorSendToClosedPlexus), Equals, true)
+	sender, _ := plx.Sender("sender_0")
+	c.Assert(errors.Is(sender.SendContext(context.Background(), 4), ErrorSendToClosedPlexus), Equals, true)
+	c.Assert(func() { sender.Send(5) }, PanicMatches, ErrorSendToClosedPlexus.Error())
+}
@@ -0,0 +1,79 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"time"
+)
+
+type LivenessSuite struct{}
+
+var (
+	_ = Suite(&LivenessSuite{})
+)
+
+// TestDeadAndAlive checks that a participant without heartbeats is dead, and it is alive again after a heartbeat.
+func (s *LivenessSuite) TestDeadAndAlive(c *C) {
+	var (
+		plx = NewPlexus(WithSendersNumber(2), WithReceiversNumber(1),
+			WithLiveness(Liveness{Timeout: 20 * time.Millisecond, Interval: 5 * time.Millisecond}))
+		events = make(chan LivenessEvent, 16)
+	)
+	defer plx.Close()
+	plx.Observe(func(e LivenessEvent) {
+		if e.Name == "sender_1" {
+			events <- e
+		}
+	})
+	sender, _ := plx.Sender("sender_1")
+
+	var e = <-events
+	c.Assert(e.Kind, Equals, "sender")
+	c.Assert(e.Alive, Equals, false)
+	c.Assert(plx.Participants()[1].Dead, Equals, true)
+	c.Assert(plx.Participants()[1].Excluded, Equals, false)
+
+	sender.Heartbeat()
+	e = <-events
+	c.Assert(e.Alive, Equals, true)
+	c.Assert(plx.Participants()[1].Dead, Equals, false)
+}
+
+// TestExclude checks that a dead sender is removed from the round requirement, so waiting participants complete
+// the round without it. The sender is required again after a heartbeat.
+func (s *LivenessSuite) TestExclude(c *C) {
+	var (
+		plx = NewPlexus(WithSendersNumber(2), WithReceiversNumber(1),
+			WithLiveness(Liveness{Timeout: 20 * time.Millisecond, Interval: 5 * time.Millisecond, Exclude: true}))
+		values = make(chan any)
+	)
+	defer plx.Close()
+	go func() {
+		for {
+			v, ok := recv0(plx)
+			if !ok {
+				return
+			}
+			values <- v
+		}
+	}()
+
+	go sendN(plx, 0, Counter(1))
+	c.Assert(<-values, Equals, Counter(1))
+	c.Assert(plx.Participants()[1].Excluded, Equals, true)
+
+	// A heartbeat returns the sender into the round requirement before the next round.
+	sender, _ := plx.Sender("sender_1")
+	sender.Heartbeat()
+	c.Assert(plx.Participants()[1].Excluded, Equals, false)
+	go sendN(plx, 0, Counter(3))
+	go sendN(plx, 1, Counter(2))
+	c.Assert(<-values, Equals, Counter(5))
+}
+
+// TestLivenessOptions checks validation of liveness options.
+func (s *LivenessSuite) TestLivenessOptions(c *C) {
+	_, err := NewPlexusE(WithSendersNumber(1), WithReceiversNumber(1), WithLiveness(Liveness{}))
+	c.Assert(err, ErrorMatches, "invalid plexus options: invalid liveness \\{Timeout:0s Interval:0s Exclude:false\\}")
+}
@@ -121,3 +121,37 @@ func (s *PlexSuite) TestRecvSend(c *C) {
 		c.Assert(v, Equals, concurrency)
 	}
 }
+
+// TestCloseUnblocksRecv checks that Plexus.Close unblocks a waiting Plexus.Recv.
+func (s *PlexSuite) TestCloseUnblocksRecv(c *C) {
+	var (
+		plx  = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		done = make(chan bool)
+	)
+	go func() {
+		v, ok := recv0(plx)
+		done <- v == nil && !ok
+	}()
+	time.Sleep(time.Millisecond)
+	plx.Close()
+	c.Assert(<-done, Equals, true)
+}
+
+// TestSendPanicsOnClose checks that a blocked Plexus.Send panics on Plexus.Close.
+func (s *PlexSuite) TestSendPanicsOnClose(c *C) {
+	var (
+		plx  = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		done = make(chan interface{})
+	)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+		send0(plx, testValue)
+	}()
+	time.Sleep(time.Millisecond)
+	plx.Close()
+	var v, ok = <-done
+	c.Assert(ok, Equals, true)
+	c.Assert(v, Equals, ErrorSendToClosedPlexus)
+}
@@ -0,0 +1,58 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+)
+
+type OptionsSuite struct{}
+
+var (
+	_ = Suite(&OptionsSuite{})
+)
+
+// TestOptionsOrder checks that options are applied in any order.
+func (s *OptionsSuite) TestOptionsOrder(c *C) {
+	plx, err := NewPlexusE(WithSelectableSenders(), WithReceiversNumber(1), WithName("plexus"), WithSendersNumber(2))
+	c.Assert(err, IsNil)
+	c.Assert(plx.Name(), Equals, "plexus")
+	c.Assert(plx.State(), Equals, MsSr)
+	c.Assert(plx.ReadySend("sender_1"), NotNil)
+}
+
+// TestNewPlexusE checks descriptive errors of an invalid configuration.
+func (s *OptionsSuite) TestNewPlexusE(c *C) {
+	for _, test := range []struct {
+		options []Option
+		err     string
+	}{
+		{[]Option{WithSendersNumber(1)}, "invalid plexus options: no receivers"},
+		{[]Option{WithReceiversNumber(1)}, "invalid plexus options: no senders"},
+		{[]Option{WithSendersNumber(0), WithReceiversNumber(0)},
+			"invalid plexus options: no senders\ninvalid plexus options: no receivers"},
+		{[]Option{WithSendersNumber(-1), WithReceiversNumber(1)},
+			"invalid plexus options: negative number of senders -1"},
+		{[]Option{WithSendersNumber(1), WithReceiversNumber(-2)},
+			"invalid plexus options: negative number of receivers -2"},
+		{[]Option{WithSenders(""), WithReceiversNumber(1)}, "invalid plexus options: sender without a name"},
+		{[]Option{WithSendersNumber(1), WithReceivers("")}, "invalid plexus options: receiver without a name"},
+		{[]Option{WithSenders("a", "a"), WithReceiversNumber(1)}, "invalid plexus options: duplicate sender 'a'"},
+		{[]Option{WithSendersNumber(1), WithReceivers("b", "b")}, "invalid plexus options: duplicate receiver 'b'"},
+		{[]Option{WithSenders("a", "b"), WithReceivers("b")},
+			"invalid plexus options: name 'b' is used for a sender and a receiver"},
+	} {
+		plx, err := NewPlexusE(test.options...)
+		c.Assert(plx, IsNil)
+		c.Assert(errors.Is(err, ErrorInvalidOptions), Equals, true)
+		c.Assert(err.Error(), Equals, test.err)
+	}
+}
+
+// TestNewPlexusPanics checks that NewPlexus panics with an error of an invalid configuration.
+func (s *OptionsSuite) TestNewPlexusPanics(c *C) {
+	c.Assert(func() {
+		NewPlexus(WithSendersNumber(1))
+	}, PanicMatches, "invalid plexus options: no receivers")
+}
@@ -0,0 +1,86 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+)
+
+type PipeSuite struct{}
+
+var (
+	_ = Suite(&PipeSuite{})
+)
+
+// TestPipe checks a FIFO order of values passed through a pipeline of two plexuses.
+func (s *PipeSuite) TestPipe(c *C) {
+	const count = 100
+	var (
+		src = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		dst = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		lnk = Pipe(src, "receiver_0", dst, "sender_0")
+	)
+	go func() {
+		for i := 0; i < count; i += 1 {
+			send0(src, i)
+		}
+	}()
+
+	for i := 0; i < count; i += 1 {
+		v, ok := recv0(dst)
+		c.Assert(ok, Equals, true)
+		c.Assert(v, Equals, i)
+	}
+	c.Assert(lnk.Err(), IsNil)
+}
+
+// TestPipeDAG checks values passed through a DAG with a fan-out and a fan-in plexuses.
+func (s *PipeSuite) TestPipeDAG(c *C) {
+	var (
+		// src passes a value to two branches.
+		src = NewPlexus(WithReceiversNumber(2), WithSendersNumber(1))
+		lb  = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		rb  = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		// dst merges values from both branches.
+		dst = NewPlexus(WithReceiversNumber(1), WithSendersNumber(2))
+	)
+	Pipe(src, "receiver_0", lb, "sender_0")
+	Pipe(src, "receiver_1", rb, "sender_0")
+	Pipe(lb, "receiver_0", dst, "sender_0")
+	Pipe(rb, "receiver_0", dst, "sender_1")
+
+	go send0(src, Counter(1))
+	v, ok := recv0(dst)
+	c.Assert(ok, Equals, true)
+	c.Assert(v, Equals, Counter(2))
+}
+
+// TestPipeError checks that a failed Link returns an error and closes the destination plexus.
+func (s *PipeSuite) TestPipeError(c *C) {
+	var (
+		src = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		dst = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		lnk = Pipe(src, "receiver_0", dst, "unknown")
+	)
+	go send0(src, testValue)
+
+	c.Assert(lnk.Wait(), ErrorMatches, "can not pipe 'receiver_0' to 'unknown': .*")
+	c.Assert(WaitLinks(lnk), NotNil)
+	<-dst.Done()
+	_, ok := recv0(dst)
+	c.Assert(ok, Equals, false)
+}
+
+// TestPipeClose checks that closing of the source plexus is propagated downstream.
+func (s *PipeSuite) TestPipeClose(c *C) {
+	var (
+		src = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		mid = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		dst = NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))
+		l1  = Pipe(src, "receiver_0", mid, "sender_0")
+		l2  = Pipe(mid, "receiver_0", dst, "sender_0")
+	)
+	src.Close()
+
+	c.Assert(WaitLinks(l1, l2), IsNil)
+	<-dst.Done()
+}
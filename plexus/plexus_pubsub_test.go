@@ -0,0 +1,167 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"time"
+)
+
+type PubSubSuite struct{}
+
+var (
+	_ = Suite(&PubSubSuite{})
+)
+
+// TestPublishSubscribe checks that all subscribers of a topic take published values in order.
+func (s *PubSubSuite) TestPublishSubscribe(c *C) {
+	const count = 10
+	ps, err := NewPubSub(2)
+	c.Assert(err, IsNil)
+	defer ps.Close()
+	s1, err := ps.Subscribe("a", count, 0)
+	c.Assert(err, IsNil)
+	c.Assert(s1.Topic(), Equals, "a")
+	s2, err := ps.Subscribe("a", count, 0)
+	c.Assert(err, IsNil)
+	s3, err := ps.Subscribe("b", count, 0)
+	c.Assert(err, IsNil)
+	c.Assert(ps.Topics(), DeepEquals, []string{"a", "b"})
+
+	for i := 0; i < count; i += 1 {
+		c.Assert(ps.Publish("a", i), IsNil)
+	}
+	c.Assert(ps.Publish("b", "b"), IsNil)
+	for i := 0; i < count; i += 1 {
+		c.Assert(<-s1.C(), Equals, i)
+		c.Assert(<-s2.C(), Equals, i)
+	}
+	c.Assert(<-s3.C(), Equals, "b")
+	c.Assert(s1.Dropped(), Equals, uint64(0))
+}
+
+// TestSlowSubscriber checks that a subscriber with a full buffer drops values and does not block other subscribers.
+func (s *PubSubSuite) TestSlowSubscriber(c *C) {
+	ps, _ := NewPubSub(2)
+	defer ps.Close()
+	slow, _ := ps.Subscribe("a", 1, 0)
+	fast, _ := ps.Subscribe("a", 3, 0)
+
+	for i := 0; i < 3; i += 1 {
+		c.Assert(ps.Publish("a", i), IsNil)
+	}
+	for i := 0; i < 3; i += 1 {
+		c.Assert(<-fast.C(), Equals, i)
+	}
+	// Values are passed to buffers after a round, so the drop of the last value is awaited.
+	for slow.Dropped() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(<-slow.C(), Equals, 0)
+	c.Assert(slow.Dropped(), Equals, uint64(2))
+	c.Assert(fast.Dropped(), Equals, uint64(0))
+}
+
+// TestTimeout checks that a subscriber with a full buffer waits for a free space for a timeout.
+func (s *PubSubSuite) TestTimeout(c *C) {
+	ps, _ := NewPubSub(1)
+	defer ps.Close()
+	sub, _ := ps.Subscribe("a", 1, time.Hour)
+
+	c.Assert(ps.Publish("a", 1), IsNil)
+	c.Assert(ps.Publish("a", 2), IsNil)
+	c.Assert(<-sub.C(), Equals, 1)
+	c.Assert(<-sub.C(), Equals, 2)
+
+	sub, _ = ps.Subscribe("b", 0, time.Millisecond)
+	c.Assert(ps.Publish("b", 1), IsNil)
+	for sub.Dropped() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestUnsubscribe checks that an unsubscribed subscription is closed, other subscriptions take values further, and
+// the topic is removed with the last subscription.
+func (s *PubSubSuite) TestUnsubscribe(c *C) {
+	ps, _ := NewPubSub(2)
+	defer ps.Close()
+	s1, _ := ps.Subscribe("a", 1, 0)
+	s2, _ := ps.Subscribe("a", 1, 0)
+
+	c.Assert(ps.Publish("a", 1), IsNil)
+	c.Assert(<-s1.C(), Equals, 1)
+	c.Assert(<-s2.C(), Equals, 1)
+
+	s1.Unsubscribe()
+	s1.Unsubscribe()
+	_, ok := <-s1.C()
+	c.Assert(ok, Equals, false)
+
+	c.Assert(ps.Publish("a", 2), IsNil)
+	c.Assert(<-s2.C(), Equals, 2)
+	// The released receiver is leased by a new subscription.
+	s3, err := ps.Subscribe("a", 1, 0)
+	c.Assert(err, IsNil)
+	_, err = ps.Subscribe("a", 1, 0)
+	c.Assert(errors.Is(err, ErrorNoFreeParticipant), Equals, true)
+
+	s2.Unsubscribe()
+	s3.Unsubscribe()
+	_, ok = <-s2.C()
+	c.Assert(ok, Equals, false)
+	_, ok = <-s3.C()
+	c.Assert(ok, Equals, false)
+	c.Assert(ps.Topics(), HasLen, 0)
+	// A value of a topic without subscribers is dropped.
+	c.Assert(ps.Publish("a", 3), IsNil)
+	c.Assert(ps.Topics(), HasLen, 0)
+}
+
+// TestUnsubscribeBlocked checks that a publisher blocked by a subscriber is released when the subscriber leaves.
+func (s *PubSubSuite) TestUnsubscribeBlocked(c *C) {
+	ps, _ := NewPubSub(2)
+	defer ps.Close()
+	s1, _ := ps.Subscribe("a", 0, time.Hour)
+	s2, _ := ps.Subscribe("a", 1, 0)
+
+	c.Assert(ps.Publish("a", 1), IsNil)
+	c.Assert(<-s2.C(), Equals, 1)
+	// The first subscriber blocks in a delivery of the first value, so the next publish waits for it.
+	var published = make(chan error)
+	go func() {
+		published <- ps.Publish("a", 2)
+	}()
+	select {
+	case <-published:
+		c.Fatal("publish is not blocked")
+	case <-time.After(10 * time.Millisecond):
+	}
+	s1.Unsubscribe()
+	c.Assert(<-published, IsNil)
+	c.Assert(<-s2.C(), Equals, 2)
+	_, ok := <-s1.C()
+	c.Assert(ok, Equals, false)
+	c.Assert(s1.Dropped(), Equals, uint64(1))
+}
+
+// TestClose checks that subscriptions are closed with the PubSub, and the closed PubSub is not usable.
+func (s *PubSubSuite) TestClose(c *C) {
+	ps, _ := NewPubSub(1)
+	s1, _ := ps.Subscribe("a", 0, 0)
+	ps.Close()
+	_, ok := <-s1.C()
+	c.Assert(ok, Equals, false)
+	s1.Unsubscribe()
+
+	c.Assert(errors.Is(ps.Publish("a", 1), ErrorPubSubClosed), Equals, true)
+	_, err := ps.Subscribe("a", 0, 0)
+	c.Assert(err, ErrorMatches, "can not subscribe to 'a': closed pubsub")
+	c.Assert(func() { ps.Close() }, PanicMatches, ErrorPubSubClosed.Error())
+}
+
+// TestNewPubSub checks validation of a capacity of topics.
+func (s *PubSubSuite) TestNewPubSub(c *C) {
+	_, err := NewPubSub(0)
+	c.Assert(err, ErrorMatches, "invalid plexus options: invalid pubsub capacity 0")
+}
@@ -0,0 +1,83 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+)
+
+type RegistrySuite struct{}
+
+var (
+	_ = Suite(&RegistrySuite{})
+)
+
+// TestRegister checks registering and lookup of plexuses by name.
+func (s *RegistrySuite) TestRegister(c *C) {
+	var (
+		r = NewRegistry()
+		b = NewPlexus(WithName("b"), WithReceiversNumber(1), WithSendersNumber(1))
+		a = NewPlexus(WithName("a"), WithReceiversNumber(2), WithSendersNumber(1))
+	)
+	c.Assert(r.Register(b), IsNil)
+	c.Assert(r.Register(a), IsNil)
+	c.Assert(r.Register(a), ErrorMatches, "can not register plexus 'a': plexus already registered")
+	c.Assert(r.Register(NewPlexus(WithReceiversNumber(1), WithSendersNumber(1))), Equals, ErrorPlexusWithoutName)
+
+	c.Assert(r.Len(), Equals, 2)
+	c.Assert(r.Names(), DeepEquals, []string{"a", "b"})
+	plx, ok := r.Lookup("a")
+	c.Assert(ok, Equals, true)
+	c.Assert(plx, Equals, a)
+	_, ok = r.Lookup("c")
+	c.Assert(ok, Equals, false)
+}
+
+// TestStats checks stats of registered plexuses.
+func (s *RegistrySuite) TestStats(c *C) {
+	var (
+		r   = NewRegistry()
+		plx = NewPlexus(WithName("a"), WithReceiversNumber(1), WithSendersNumber(1))
+	)
+	c.Assert(r.Register(plx), IsNil)
+	c.Assert(r.Register(NewPlexus(WithName("b"), WithReceiversNumber(2), WithSendersNumber(2))), IsNil)
+
+	go send0(plx, testValue)
+	recv0(plx)
+
+	var stats []Stats
+	for st := range r.Stats() {
+		stats = append(stats, st)
+	}
+	c.Assert(stats, DeepEquals, []Stats{{
+		Name:      "a",
+		Mode:      SsSr,
+		Senders:   1,
+		Receivers: 1,
+		Active:    true,
+		Rounds:    1,
+		Recvs:     1,
+		Sends:     1,
+	}, {
+		Name:      "b",
+		Mode:      MsMr,
+		Senders:   2,
+		Receivers: 2,
+	}})
+}
+
+// TestClose checks that Registry.Close closes all registered plexuses.
+func (s *RegistrySuite) TestClose(c *C) {
+	var (
+		r = NewRegistry()
+		a = NewPlexus(WithName("a"), WithReceiversNumber(1), WithSendersNumber(1))
+		b = NewPlexus(WithName("b"), WithReceiversNumber(1), WithSendersNumber(1))
+	)
+	c.Assert(r.Register(a), IsNil)
+	c.Assert(r.Register(b), IsNil)
+	a.Close()
+
+	r.Close()
+	for st := range r.Stats() {
+		c.Assert(st.Closed, Equals, true)
+	}
+}
@@ -0,0 +1,97 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type ScatterSuite struct{}
+
+var (
+	_ = Suite(&ScatterSuite{})
+)
+
+// TestCall checks that concurrent calls take merged responses to their own requests.
+func (s *ScatterSuite) TestCall(c *C) {
+	const (
+		callers = 10
+		calls   = 100
+	)
+	var workers = []string{"worker_0", "worker_1", "worker_2"}
+	sg, err := NewScatterGather(workers...)
+	c.Assert(err, IsNil)
+	defer sg.Close()
+	for _, name := range workers {
+		go sg.Serve(name, func(req any) Mergeable {
+			return Counter(req.(int))
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < calls; j += 1 {
+				var req = i*calls + j
+				res, err := sg.Call(context.Background(), req)
+				c.Check(err, IsNil)
+				c.Check(res, Equals, Counter(len(workers)*req))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestCallContext checks that a context cancels a call, and responses to the cancelled call are dropped.
+func (s *ScatterSuite) TestCallContext(c *C) {
+	sg, err := NewScatterGather("worker_0", "worker_1")
+	c.Assert(err, IsNil)
+	defer sg.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = sg.Call(ctx, 1)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	for _, name := range []string{"worker_0", "worker_1"} {
+		go sg.Serve(name, func(req any) Mergeable {
+			return Counter(req.(int))
+		})
+	}
+	res, err := sg.Call(context.Background(), 2)
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, Counter(4))
+}
+
+// TestNewScatterGather checks validation of worker names.
+func (s *ScatterSuite) TestNewScatterGather(c *C) {
+	_, err := NewScatterGather()
+	c.Assert(errors.Is(err, ErrorInvalidOptions), Equals, true)
+	_, err = NewScatterGather("worker_0", "worker_0")
+	c.Assert(err, ErrorMatches, fmt.Sprintf("can not create scatter plexus: %s: duplicate receiver 'worker_0'",
+		ErrorInvalidOptions))
+}
+
+// TestCallClosed checks that waiting and new calls fail when the ScatterGather is closed.
+func (s *ScatterSuite) TestCallClosed(c *C) {
+	sg, err := NewScatterGather("worker_0")
+	c.Assert(err, IsNil)
+	var errs = make(chan error)
+	go func() {
+		_, err := sg.Call(context.Background(), 1)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sg.Close()
+	c.Assert(errors.Is(<-errs, ErrorCallClosed), Equals, true)
+
+	_, err = sg.Call(context.Background(), 2)
+	c.Assert(errors.Is(err, ErrorCallClosed), Equals, true)
+}
@@ -0,0 +1,126 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"strings"
+)
+
+type TopologySuite struct{}
+
+var (
+	_ = Suite(&TopologySuite{})
+)
+
+// testTopology describes a DAG: `src` passes a value to branches `lb` and `rb`, `dst` merges values from them.
+const testTopology = `{
+	"plexuses": [
+		{"name": "src", "senders": ["in"], "receivers": ["l", "r"], "mode": "SsMr"},
+		{"name": "lb", "senders": ["in"], "receivers": ["out"]},
+		{"name": "rb", "senders": ["in"], "receivers": ["out"]},
+		{"name": "dst", "senders": ["l", "r"], "receivers": ["out"], "mode": "MsSr"}
+	],
+	"links": [
+		{"from": {"plexus": "src", "name": "l"}, "to": {"plexus": "lb", "name": "in"}},
+		{"from": {"plexus": "src", "name": "r"}, "to": {"plexus": "rb", "name": "in"}},
+		{"from": {"plexus": "lb", "name": "out"}, "to": {"plexus": "dst", "name": "l"}},
+		{"from": {"plexus": "rb", "name": "out"}, "to": {"plexus": "dst", "name": "r"}}
+	],
+	"inputs": [{"plexus": "src", "name": "in"}],
+	"outputs": [{"plexus": "dst", "name": "out"}]
+}`
+
+// TestBuild checks a graph built from a topology description.
+func (s *TopologySuite) TestBuild(c *C) {
+	t, err := ParseTopology(strings.NewReader(testTopology))
+	c.Assert(err, IsNil)
+	g, err := t.Build()
+	c.Assert(err, IsNil)
+	c.Assert(g.Plexuses(), HasLen, 4)
+	c.Assert(g.Links(), HasLen, 4)
+
+	src, ok := g.Plexus("src")
+	c.Assert(ok, Equals, true)
+	c.Assert(src.Name(), Equals, "src")
+	dst, ok := g.Plexus("dst")
+	c.Assert(ok, Equals, true)
+
+	go src.Send("in", Counter(1))
+	v, ok := dst.Recv("out")
+	c.Assert(ok, Equals, true)
+	c.Assert(v, Equals, Counter(2))
+
+	g.Close()
+	c.Assert(g.Wait(), IsNil)
+}
+
+// TestParseUnknownField checks that a topology description with unknown fields is rejected.
+func (s *TopologySuite) TestParseUnknownField(c *C) {
+	_, err := ParseTopology(strings.NewReader(`{"plexuses": [], "unknown": 1}`))
+	c.Assert(err, ErrorMatches, "can not parse topology: .*unknown.*")
+}
+
+// TestValidate checks errors of an inconsistent topology description.
+func (s *TopologySuite) TestValidate(c *C) {
+	var tests = []struct {
+		topology Topology
+		expected string
+	}{{
+		topology: Topology{Plexuses: []PlexusSpec{
+			{Name: "a", Senders: []string{"s"}, Receivers: []string{"r"}},
+			{Name: "a", Senders: []string{"s"}, Receivers: []string{"r"}},
+		}},
+		expected: "duplicate plexus 'a'",
+	}, {
+		topology: Topology{Plexuses: []PlexusSpec{
+			{Name: "a", Senders: []string{"s"}},
+		}},
+		expected: "plexus 'a' has no receivers",
+	}, {
+		topology: Topology{
+			Plexuses: []PlexusSpec{{Name: "a", Senders: []string{"x"}, Receivers: []string{"x"}}},
+			Inputs:   []Endpoint{{"a", "x"}},
+			Outputs:  []Endpoint{{"a", "x"}},
+		},
+		expected: "name 'x' of plexus 'a' is used for a sender and a receiver",
+	}, {
+		topology: Topology{Plexuses: []PlexusSpec{
+			{Name: "a", Senders: []string{"s"}, Receivers: []string{"r"}, Mode: "MsMr"},
+		}},
+		expected: "plexus 'a' does not match mode 'MsMr'",
+	}, {
+		topology: Topology{
+			Plexuses: []PlexusSpec{{Name: "a", Senders: []string{"s"}, Receivers: []string{"r"}}},
+			Links:    []LinkSpec{{From: Endpoint{"a", "r"}, To: Endpoint{"b", "s"}}},
+		},
+		expected: "unknown sender 'b.s'",
+	}, {
+		topology: Topology{
+			Plexuses: []PlexusSpec{{Name: "a", Senders: []string{"s"}, Receivers: []string{"r"}}},
+			Inputs:   []Endpoint{{"a", "s"}},
+		},
+		expected: "dangling receiver 'a.r'",
+	}, {
+		topology: Topology{
+			Plexuses: []PlexusSpec{
+				{Name: "a", Senders: []string{"s"}, Receivers: []string{"r"}},
+				{Name: "b", Senders: []string{"s"}, Receivers: []string{"r"}},
+			},
+			Links: []LinkSpec{
+				{From: Endpoint{"a", "r"}, To: Endpoint{"b", "s"}},
+				{From: Endpoint{"b", "r"}, To: Endpoint{"a", "s"}},
+			},
+		},
+		expected: "cycle [a b a]",
+	}}
+	for _, test := range tests {
+		var err = test.topology.Validate()
+		c.Assert(errors.Is(err, ErrorInvalidTopology), Equals, true)
+		c.Assert(strings.Contains(err.Error(), test.expected), Equals, true, Commentf("%v", err))
+
+		_, err = test.topology.Build()
+		c.Assert(err, NotNil)
+	}
+}
@@ -0,0 +1,77 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+type TraceSuite struct{}
+
+var (
+	_ = Suite(&TraceSuite{})
+)
+
+// TestRecorder checks events recorded for a round of the MsSr plexus.
+func (s *TraceSuite) TestRecorder(c *C) {
+	var (
+		rec = NewRecorder()
+		plx = NewPlexus(WithName("a"), WithReceiversNumber(1), WithSendersNumber(2), WithRecorder(rec))
+		wg  sync.WaitGroup
+	)
+	for i := 0; i < 2; i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sendN(plx, i, Counter(i+1))
+		}(i)
+	}
+	v, _ := recv0(plx)
+	c.Assert(v, Equals, Counter(3))
+	wg.Wait()
+	// Two participants of three are blocked in a round.
+	c.Assert(rec.Len(), Equals, 4)
+
+	var buf bytes.Buffer
+	_, err := rec.WriteTo(&buf)
+	c.Assert(err, IsNil)
+	var doc struct {
+		TraceEvents []struct {
+			Name string         `json:"name"`
+			Cat  string         `json:"cat"`
+			Ph   string         `json:"ph"`
+			Ts   float64        `json:"ts"`
+			Dur  float64        `json:"dur"`
+			Pid  int            `json:"pid"`
+			Tid  int            `json:"tid"`
+			Args map[string]any `json:"args"`
+		} `json:"traceEvents"`
+	}
+	c.Assert(json.Unmarshal(buf.Bytes(), &doc), IsNil)
+
+	var (
+		names   = map[string]int{}
+		threads = map[string]bool{}
+	)
+	for _, e := range doc.TraceEvents {
+		c.Assert(e.Pid, Equals, 1)
+		switch e.Ph {
+		case "M":
+			if e.Name == "process_name" {
+				c.Assert(e.Args["name"], Equals, "a")
+			} else {
+				threads[e.Args["name"].(string)] = true
+			}
+		case "X":
+			names[e.Name] += 1
+			c.Assert(e.Ts >= 0, Equals, true)
+		default:
+			c.Fatalf("unexpected event phase %s", e.Ph)
+		}
+	}
+	c.Assert(names, DeepEquals, map[string]int{"wait": 2, "round": 1, "merge": 1})
+	c.Assert(threads, HasLen, 3)
+}
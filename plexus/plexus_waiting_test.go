@@ -0,0 +1,58 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"fmt"
+	"time"
+)
+
+type WaitingSuite struct{}
+
+var (
+	_ = Suite(&WaitingSuite{})
+)
+
+// TestWaiting checks blocked senders and receivers of a plexus.
+func (s *WaitingSuite) TestWaiting(c *C) {
+	var plx = NewPlexus(WithName("a"), WithReceiversNumber(2), WithSendersNumber(2))
+	c.Assert(plx.Waiting(), HasLen, 0)
+
+	go sendN(plx, 1, Counter(1))
+	go sendN(plx, 1, Counter(1))
+	go recvN(plx, 0)
+	for len(plx.Waiting()) < 2 || plx.Waiting()[0].Queued < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(time.Millisecond)
+
+	var ww = plx.Waiting()
+	c.Assert(ww, HasLen, 2)
+	c.Assert(ww[0].Name, Equals, "sender_1")
+	c.Assert(ww[0].Kind, Equals, "sender")
+	c.Assert(ww[0].Queued, Equals, 2)
+	c.Assert(ww[0].Waited >= time.Millisecond, Equals, true)
+	c.Assert(ww[1].Name, Equals, "receiver_0")
+	c.Assert(ww[1].Kind, Equals, "receiver")
+	c.Assert(ww[1].Queued, Equals, 1)
+}
+
+// TestString checks a short description of a plexus.
+func (s *WaitingSuite) TestString(c *C) {
+	var plx = NewPlexus(WithName("a"), WithReceiversNumber(2), WithSendersNumber(1))
+	c.Assert(plx.String(), Equals, `plexus "a" mode=SsMr closed=false active=false senders=0/1 receivers=0/2`)
+
+	go recvN(plx, 1)
+	for plx.Stats().WaitingReceivers == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(fmt.Sprintf("%s", plx), Equals,
+		`plexus "a" mode=SsMr closed=false active=true senders=0/1 receivers=1/2`)
+	c.Assert(fmt.Sprintf("%+v", plx), Matches,
+		`plexus "a" mode=SsMr closed=false active=true senders=0/1 receivers=1/2\n\treceiver receiver_1 queued=1 waited=.*`)
+
+	plx.Close()
+	c.Assert(fmt.Sprintf("%v", plx), Equals,
+		`plexus "a" mode=SsMr closed=true active=true senders=0/1 receivers=0/2`)
+}
@@ -0,0 +1,144 @@
+package plexus_test
+
+import (
+	. "github.com/alxmsl/prmtvs/plexus"
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alxmsl/prmtvs/plexus/plexustest"
+)
+
+type WindowSuite struct{}
+
+var (
+	_ = Suite(&WindowSuite{})
+)
+
+// t0 is a start time of windows in tests.
+var t0 = time.Unix(1000, 0)
+
+// tick advances a given clock by a given duration, when a window waits for the time.
+func tick(clock *plexustest.Clock, d time.Duration) {
+	clock.BlockUntil(1)
+	clock.Advance(d)
+}
+
+// recvAllWindow receives the next result of a given window by all receivers with given names simultaneously.
+func recvAllWindow(w *Window, names ...string) []any {
+	var (
+		wg     sync.WaitGroup
+		result = make([]any, len(names))
+	)
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result[i], _ = w.Recv(name)
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// TestTumbling checks that tumbling windows emit values merged during each window, and empty windows are skipped.
+func (s *WindowSuite) TestTumbling(c *C) {
+	var clock = plexustest.NewClock(t0)
+	w, err := NewWindow(WindowSpec{Size: 10 * time.Second, Clock: clock}, "receiver_0", "receiver_1")
+	c.Assert(err, IsNil)
+	defer w.Close()
+
+	for i := 0; i < 3; i += 1 {
+		c.Assert(w.Add(Counter(1)), IsNil)
+	}
+	tick(clock, 10*time.Second)
+	var expected = WindowResult{Start: t0, End: t0.Add(10 * time.Second), Value: Counter(3)}
+	c.Assert(recvAllWindow(w, "receiver_0", "receiver_1"), DeepEquals, []any{expected, expected})
+
+	tick(clock, 10*time.Second)
+	c.Assert(w.Add(Counter(2)), IsNil)
+	tick(clock, 10*time.Second)
+	expected = WindowResult{Start: t0.Add(20 * time.Second), End: t0.Add(30 * time.Second), Value: Counter(2)}
+	c.Assert(recvAllWindow(w, "receiver_0", "receiver_1"), DeepEquals, []any{expected, expected})
+}
+
+// TestSliding checks that sliding windows merge values of all slides of each window.
+func (s *WindowSuite) TestSliding(c *C) {
+	var clock = plexustest.NewClock(t0)
+	w, err := NewWindow(WindowSpec{Size: 20 * time.Second, Slide: 10 * time.Second, Clock: clock}, "receiver_0")
+	c.Assert(err, IsNil)
+	defer w.Close()
+
+	c.Assert(w.Add(Counter(1)), IsNil)
+	tick(clock, 10*time.Second)
+	v, _ := w.Recv("receiver_0")
+	c.Assert(v, DeepEquals, WindowResult{Start: t0.Add(-10 * time.Second), End: t0.Add(10 * time.Second),
+		Value: Counter(1)})
+
+	c.Assert(w.Add(Counter(2)), IsNil)
+	// The value is not late, because its slide belongs to the next window.
+	c.Assert(w.AddAt(t0.Add(5*time.Second), Counter(4)), IsNil)
+	tick(clock, 10*time.Second)
+	v, _ = w.Recv("receiver_0")
+	c.Assert(v, DeepEquals, WindowResult{Start: t0, End: t0.Add(20 * time.Second), Value: Counter(7)})
+
+	tick(clock, 10*time.Second)
+	v, _ = w.Recv("receiver_0")
+	c.Assert(v, DeepEquals, WindowResult{Start: t0.Add(10 * time.Second), End: t0.Add(30 * time.Second),
+		Value: Counter(2)})
+}
+
+// TestAlignment checks that windows are aligned to multiples of a slide since the Unix epoch.
+func (s *WindowSuite) TestAlignment(c *C) {
+	const size = 7 * time.Second
+	var clock = plexustest.NewClock(t0)
+	w, err := NewWindow(WindowSpec{Size: size, Clock: clock}, "receiver_0")
+	c.Assert(err, IsNil)
+	defer w.Close()
+
+	c.Assert(w.Add(Counter(1)), IsNil)
+	tick(clock, size)
+	v, _ := w.Recv("receiver_0")
+	var res = v.(WindowResult)
+	c.Assert(res.Start.UnixNano()%int64(size), Equals, int64(0))
+	c.Assert(res.Start, DeepEquals, time.Unix(994, 0))
+	c.Assert(res.End, DeepEquals, time.Unix(1001, 0))
+}
+
+// TestLate checks late policies of windows.
+func (s *WindowSuite) TestLate(c *C) {
+	for _, test := range []struct {
+		late    LatePolicy
+		err     error
+		dropped uint64
+		value   Counter
+	}{
+		{LateDrop, nil, 1, 1},
+		{LateNext, nil, 0, 3},
+		{LateFail, ErrorLateValue, 0, 1},
+	} {
+		var clock = plexustest.NewClock(t0)
+		w, err := NewWindow(WindowSpec{Size: time.Second, Late: test.late, Clock: clock}, "receiver_0")
+		c.Assert(err, IsNil)
+		c.Assert(w.Add(Counter(1)), IsNil)
+		err = w.AddAt(clock.Now().Add(-time.Second), Counter(2))
+		c.Assert(errors.Is(err, test.err), Equals, true)
+		c.Assert(w.Dropped(), Equals, test.dropped)
+		tick(clock, time.Second)
+		v, _ := w.Recv("receiver_0")
+		c.Assert(v.(WindowResult).Value, Equals, test.value)
+		w.Close()
+	}
+}
+
+// TestNewWindow checks validation of windows.
+func (s *WindowSuite) TestNewWindow(c *C) {
+	_, err := NewWindow(WindowSpec{Size: 0}, "receiver_0")
+	c.Assert(errors.Is(err, ErrorInvalidOptions), Equals, true)
+	_, err = NewWindow(WindowSpec{Size: 3 * time.Second, Slide: 2 * time.Second}, "receiver_0")
+	c.Assert(err, ErrorMatches, "invalid plexus options: invalid window size 3s and slide 2s")
+	_, err = NewWindow(WindowSpec{Size: time.Second})
+	c.Assert(err, ErrorMatches, "can not create window: invalid plexus options: no receivers")
+}
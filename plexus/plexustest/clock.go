@@ -0,0 +1,73 @@
+package plexustest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock struct represents a manual clock which implements a plexus.Clock. Time moves only by Clock.Advance.
+type Clock struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []waiter
+}
+
+// waiter struct represents a channel of a Clock.After call and a time to fire it.
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewClock creates a Clock with a given current time.
+func NewClock(now time.Time) *Clock {
+	var c = &Clock{now: now}
+	c.cond = sync.NewCond(&c.lock)
+	return c
+}
+
+// Now returns the current time of the Clock.
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// After returns a channel which delivers the current time, when the Clock is advanced by a given duration.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var ch = make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves the current time by a given duration, and it fires channels of expired Clock.After calls.
+func (c *Clock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+	var waiters = c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+// BlockUntil blocks till a given number of Clock.After calls wait for the time.
+func (c *Clock) BlockUntil(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
@@ -0,0 +1,213 @@
+package plexustest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alxmsl/prmtvs/plexus"
+)
+
+// ErrorNotLinearizable defines error for a case when a history of calls violates semantics of a Plexus.
+var ErrorNotLinearizable = errors.New("history is not linearizable")
+
+// Call struct represents a finished call of a Plexus with start and end times.
+type Call struct {
+	Op    Op
+	Value any  // Value is a value returned by a receive call.
+	OK    bool // OK is true if the call is finished without a panic and a receive call returns a value.
+	Begin time.Time
+	End   time.Time
+}
+
+// Calls returns calls of a History.
+func (h History) Calls() []Call {
+	var result = make([]Call, 0, len(h))
+	for _, ev := range h {
+		result = append(result, Call{Op: ev.Op, Value: ev.Value, OK: ev.OK, Begin: ev.Begin, End: ev.End})
+	}
+	return result
+}
+
+// Log struct records calls of a Plexus with start and end times. It is safe for a concurrent use.
+type Log struct {
+	lock  sync.Mutex
+	calls []Call
+}
+
+// NewLog creates an empty Log object.
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Send calls Plexus.Send and records the call. A panic of the call is recorded and passed through.
+func (l *Log) Send(plx *plexus.Plexus, name string, value any) {
+	var c = Call{Op: Send(name, value), Begin: time.Now()}
+	defer l.add(&c)
+	plx.Send(name, value)
+	c.OK = true
+}
+
+// Recv calls Plexus.Recv and records the call.
+func (l *Log) Recv(plx *plexus.Plexus, name string) (any, bool) {
+	var c = Call{Op: Recv(name), Begin: time.Now()}
+	defer l.add(&c)
+	c.Value, c.OK = plx.Recv(name)
+	return c.Value, c.OK
+}
+
+// Close calls Plexus.Close and records the call. A panic of the call is recorded and passed through.
+func (l *Log) Close(plx *plexus.Plexus) {
+	var c = Call{Op: Close(), Begin: time.Now()}
+	defer l.add(&c)
+	plx.Close()
+	c.OK = true
+}
+
+// Calls returns all recorded calls.
+func (l *Log) Calls() []Call {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]Call{}, l.calls...)
+}
+
+func (l *Log) add(c *Call) {
+	c.End = time.Now()
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.calls = append(l.calls, *c)
+}
+
+// CheckHistory verifies a history of calls of a single Plexus. Each round takes one call of each sender and each
+// receiver in the order of calls, so the k-th successful call of each participant belongs to the k-th round. Calls of
+// a participant are expected not to overlap. CheckHistory reports:
+//   - receivers of a round which observe different values;
+//   - rounds which deliver a value different from a sent one. Values of multiple senders are merged, if they
+//     implement plexus.Mergeable interface;
+//   - rounds which are delivered without sends or received before sent, and sends which are not delivered, so each
+//     value is delivered exactly once;
+//   - calls which succeed after the Plexus is closed.
+//
+// All violations are joined together.
+func CheckHistory(calls []Call) error {
+	var (
+		errs    []error
+		invalid = func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrorNotLinearizable, fmt.Sprintf(format, args...)))
+		}
+		sends  = map[string][]Call{}
+		recvs  = map[string][]Call{}
+		closed time.Time
+	)
+	for _, c := range calls {
+		switch {
+		case !c.OK:
+		case c.Op.Kind == KindSend || c.Op.Kind == KindSendReady:
+			sends[c.Op.Name] = append(sends[c.Op.Name], c)
+		case c.Op.Kind == KindRecv:
+			recvs[c.Op.Name] = append(recvs[c.Op.Name], c)
+		case c.Op.Kind == KindClose:
+			if closed.IsZero() || c.End.Before(closed) {
+				closed = c.End
+			}
+		}
+	}
+	var senders, receivers = sortedCalls(sends), sortedCalls(recvs)
+
+	var rounds int
+	for _, name := range receivers {
+		rounds = max(rounds, len(recvs[name]))
+	}
+	for k := 0; k < rounds; k += 1 {
+		// All receivers of a round observe the same value.
+		var first *Call
+		for _, name := range receivers {
+			if k >= len(recvs[name]) {
+				continue
+			}
+			var c = &recvs[name][k]
+			if first == nil {
+				first = c
+			} else if !reflect.DeepEqual(c.Value, first.Value) {
+				invalid("round %d: %s observes %v, %s observes %v", k+1, first.Op, first.Value, c.Op, c.Value)
+			}
+		}
+
+		// The value is sent before it is received.
+		var values = make([]any, 0, len(senders))
+		for _, name := range senders {
+			if k >= len(sends[name]) {
+				invalid("round %d: value %v is delivered without a send of '%s'", k+1, first.Value, name)
+				continue
+			}
+			var s = sends[name][k]
+			values = append(values, s.Op.Value)
+			for _, rname := range receivers {
+				if k < len(recvs[rname]) && recvs[rname][k].End.Before(s.Begin) {
+					invalid("round %d: %s is finished before %s is started", k+1, recvs[rname][k].Op, s.Op)
+				}
+			}
+		}
+		if len(values) == len(senders) && len(values) > 0 {
+			if expected, ok := mergeValues(values); ok && !reflect.DeepEqual(expected, first.Value) {
+				invalid("round %d: %v is delivered instead of %v", k+1, first.Value, expected)
+			}
+		}
+	}
+
+	// Each successful send is delivered.
+	if len(receivers) > 0 {
+		for _, name := range senders {
+			for _, s := range sends[name][min(rounds, len(sends[name])):] {
+				invalid("%s is not delivered", s.Op)
+			}
+		}
+	}
+
+	// Nothing succeeds after close.
+	if !closed.IsZero() {
+		for _, c := range calls {
+			if c.OK && c.Op.Kind != KindClose && c.Begin.After(closed) {
+				invalid("%s succeeds after close", c.Op)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sortedCalls sorts calls of each participant by start times and returns names of participants in a sorted order.
+func sortedCalls(calls map[string][]Call) []string {
+	var names = make([]string, 0, len(calls))
+	for name, cc := range calls {
+		names = append(names, name)
+		sort.SliceStable(cc, func(i, j int) bool {
+			return cc[i].Begin.Before(cc[j].Begin)
+		})
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeValues returns a value expected to be delivered for given values of senders. It returns FALSE if values of
+// multiple senders can not be merged.
+func mergeValues(values []any) (any, bool) {
+	if len(values) == 1 {
+		return values[0], true
+	}
+	var res plexus.Mergeable
+	for _, v := range values {
+		m, ok := v.(plexus.Mergeable)
+		if !ok {
+			return nil, false
+		}
+		if res == nil {
+			res = m
+		} else {
+			res = res.Merge(m)
+		}
+	}
+	return res, true
+}
@@ -0,0 +1,130 @@
+package plexustest_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/alxmsl/prmtvs/plexus"
+	"github.com/alxmsl/prmtvs/plexus/plexustest"
+)
+
+type HistorySuite struct{}
+
+var (
+	_ = Suite(&HistorySuite{})
+)
+
+// at returns a time of a given tick of a synthetic history.
+func at(tick int) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(tick) * time.Millisecond)
+}
+
+func call(op plexustest.Op, value any, ok bool, begin, end int) plexustest.Call {
+	return plexustest.Call{Op: op, Value: value, OK: ok, Begin: at(begin), End: at(end)}
+}
+
+// TestCheckHistory checks a valid history of the SsMr plexus.
+func (s *HistorySuite) TestCheckHistory(c *C) {
+	var calls = []plexustest.Call{
+		call(plexustest.Send("sender_0", 1), nil, true, 0, 2),
+		call(plexustest.Recv("receiver_0"), 1, true, 1, 3),
+		call(plexustest.Recv("receiver_1"), 1, true, 2, 3),
+		call(plexustest.Send("sender_0", 2), nil, true, 4, 6),
+		call(plexustest.Recv("receiver_0"), 2, true, 5, 6),
+		call(plexustest.Recv("receiver_1"), 2, true, 6, 6),
+		call(plexustest.Close(), nil, true, 7, 8),
+		call(plexustest.Recv("receiver_0"), nil, false, 9, 9),
+	}
+	c.Assert(plexustest.CheckHistory(calls), IsNil)
+}
+
+// TestCheckHistoryViolations checks violations of invalid histories.
+func (s *HistorySuite) TestCheckHistoryViolations(c *C) {
+	var tests = []struct {
+		calls    []plexustest.Call
+		expected string
+	}{{
+		calls: []plexustest.Call{
+			call(plexustest.Send("sender_0", 1), nil, true, 0, 2),
+			call(plexustest.Recv("receiver_0"), 1, true, 1, 3),
+			call(plexustest.Recv("receiver_1"), 2, true, 1, 3),
+		},
+		expected: ".*round 1: recv\\(receiver_0\\) observes 1, recv\\(receiver_1\\) observes 2.*",
+	}, {
+		calls: []plexustest.Call{
+			call(plexustest.Send("sender_0", plexus.Counter(1)), nil, true, 0, 2),
+			call(plexustest.Send("sender_1", plexus.Counter(2)), nil, true, 0, 2),
+			call(plexustest.Recv("receiver_0"), plexus.Counter(4), true, 1, 3),
+		},
+		expected: ".*round 1: 4 is delivered instead of 3.*",
+	}, {
+		calls: []plexustest.Call{
+			call(plexustest.Send("sender_0", 1), nil, true, 0, 2),
+			call(plexustest.Recv("receiver_0"), 1, true, 1, 3),
+			call(plexustest.Recv("receiver_0"), 1, true, 4, 5),
+		},
+		expected: ".*round 2: value 1 is delivered without a send of 'sender_0'.*",
+	}, {
+		calls: []plexustest.Call{
+			call(plexustest.Recv("receiver_0"), 1, true, 0, 1),
+			call(plexustest.Send("sender_0", 1), nil, true, 2, 3),
+		},
+		expected: ".*round 1: recv\\(receiver_0\\) is finished before send\\(sender_0, 1\\) is started.*",
+	}, {
+		calls: []plexustest.Call{
+			call(plexustest.Send("sender_0", 1), nil, true, 0, 2),
+			call(plexustest.Send("sender_0", 2), nil, true, 3, 4),
+			call(plexustest.Recv("receiver_0"), 1, true, 1, 2),
+		},
+		expected: ".*send\\(sender_0, 2\\) is not delivered.*",
+	}, {
+		calls: []plexustest.Call{
+			call(plexustest.Close(), nil, true, 0, 1),
+			call(plexustest.Send("sender_0", 1), nil, true, 2, 3),
+			call(plexustest.Recv("receiver_0"), 1, true, 2, 3),
+		},
+		expected: "(?s).*send\\(sender_0, 1\\) succeeds after close.*recv\\(receiver_0\\) succeeds after close.*",
+	}}
+	for _, test := range tests {
+		var err = plexustest.CheckHistory(test.calls)
+		c.Assert(errors.Is(err, plexustest.ErrorNotLinearizable), Equals, true)
+		c.Assert(err, ErrorMatches, test.expected)
+	}
+}
+
+// TestLog checks a history of the MsMr plexus recorded by concurrent participants.
+func (s *HistorySuite) TestLog(c *C) {
+	const count = 100
+	var (
+		log = plexustest.NewLog()
+		plx = plexus.NewPlexus(plexus.WithReceiversNumber(3), plexus.WithSendersNumber(2))
+		wg  sync.WaitGroup
+	)
+	for _, name := range []string{"sender_0", "sender_1"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < count; i += 1 {
+				log.Send(plx, name, plexus.Counter(i))
+			}
+		}(name)
+	}
+	for _, name := range []string{"receiver_0", "receiver_1", "receiver_2"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < count; i += 1 {
+				log.Recv(plx, name)
+			}
+		}(name)
+	}
+	wg.Wait()
+	log.Close(plx)
+	log.Recv(plx, "receiver_0")
+
+	c.Assert(log.Calls(), HasLen, 5*count+2)
+	c.Assert(plexustest.CheckHistory(log.Calls()), IsNil)
+}
@@ -0,0 +1,439 @@
+// Package plexustest provides a harness to test interleavings of plexus operations.
+//
+// A Scenario describes actors: sequences of Send, SendReady, Recv and Close operations on a single Plexus. A scheduler
+// starts operations of actors one by one in a controlled order. After each operation is started, the scheduler waits
+// till the Plexus is quiescent: each running operation is either finished or blocked in a queue of the Plexus. So an
+// interleaving is defined by a Schedule: a sequence of indexes of actors, and it is reproducible.
+//
+// Operations are ordered at call boundaries: an operation is started when all other operations are finished or
+// blocked. The scheduler does not preempt an operation inside a call. A round takes values and channels from queues
+// in the general lock of a Plexus, so a started round is completed regardless of a following close, and a close is
+// ordered before or after a round, but never inside it. A release of ready-channels of selectable senders happens in
+// the general lock as well: a SendReady operation waits for its ready-channel as soon as the previous operation of its
+// actor is finished, and it is scheduled only after the channel is released.
+//
+// Enumerate explores all schedules of a Scenario. Sample explores random schedules defined by seeds. Both report
+// a failed schedule with a Failure error, which contains a seed and a schedule to reproduce it with Run.
+//
+// Clock is a manual clock to control time of helpers built on a Plexus, e.g. a plexus.Window.
+package plexustest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/alxmsl/prmtvs/plexus"
+)
+
+// ErrorNotQuiescent defines error for a case when a Plexus does not become quiescent in a QuiescenceTimeout.
+var ErrorNotQuiescent = errors.New("plexus is not quiescent")
+
+// QuiescenceTimeout defines a maximal time to wait till a Plexus is quiescent after an operation is started.
+var QuiescenceTimeout = time.Second
+
+// Kind defines a kind of an operation.
+type Kind int
+
+const (
+	KindSend Kind = iota
+	KindRecv
+	KindClose
+	KindSendReady
+)
+
+// Op struct represents an operation on a Plexus.
+type Op struct {
+	Kind  Kind
+	Name  string // Name is a name of a sender or a receiver.
+	Value any    // Value is a value of a send operation.
+}
+
+// Send returns a Plexus.Send operation for a given sender (by name).
+func Send(name string, value any) Op {
+	return Op{Kind: KindSend, Name: name, Value: value}
+}
+
+// SendReady returns a Plexus.Send operation for a given sender (by name) of a Plexus with selectable senders. The send
+// is started after a ready-channel of the sender is released. See Plexus.ReadySend for details.
+func SendReady(name string, value any) Op {
+	return Op{Kind: KindSendReady, Name: name, Value: value}
+}
+
+// Recv returns a Plexus.Recv operation for a given receiver (by name).
+func Recv(name string) Op {
+	return Op{Kind: KindRecv, Name: name}
+}
+
+// Close returns a Plexus.Close operation.
+func Close() Op {
+	return Op{Kind: KindClose}
+}
+
+func (op Op) String() string {
+	switch op.Kind {
+	case KindSend:
+		return fmt.Sprintf("send(%s, %v)", op.Name, op.Value)
+	case KindRecv:
+		return fmt.Sprintf("recv(%s)", op.Name)
+	case KindSendReady:
+		return fmt.Sprintf("send-ready(%s, %v)", op.Name, op.Value)
+	default:
+		return "close"
+	}
+}
+
+// Actor is a sequence of operations which are executed one by one by a single goroutine.
+type Actor []Op
+
+// Event struct represents a finished operation.
+type Event struct {
+	Actor int  // Actor is an index of an actor in a Scenario.
+	Step  int  // Step is an index of an operation of the actor.
+	Op    Op   // Op is the operation.
+	Start int  // Start is a sequential number of the operation start in the run.
+	Value any  // Value is a value returned by a receive operation.
+	OK    bool // OK is true if the operation is finished without a panic and a receive operation returns a value.
+	Panic any  // Panic is a value of a panic of the operation.
+
+	Begin time.Time // Begin is a time when the operation is started.
+	End   time.Time // End is a time when the operation is finished.
+}
+
+// History is a set of finished operations in the order of finish.
+type History []Event
+
+// Schedule is a sequence of indexes of actors in the order of operation starts.
+type Schedule []int
+
+// Scenario struct describes actors of a Plexus and a check of results.
+type Scenario struct {
+	// New creates a Plexus for a run.
+	New func() *plexus.Plexus
+	// Actors is a set of actors.
+	Actors []Actor
+	// Check verifies a history of a run. The history contains all started operations: operations which are blocked
+	// at the end of the run are finished by closing of the Plexus. SendReady operations which are not released till
+	// the end of the run are not started.
+	Check func(h History) error
+}
+
+// Failure struct represents a failed run.
+type Failure struct {
+	Seed     int64 // Seed is a seed of a random schedule. It is zero for an enumerated schedule.
+	Schedule Schedule
+	History  History
+	Err      error
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("seed %d, schedule %v: %v", f.Seed, f.Schedule, f.Err)
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// Run runs a Scenario with a given Schedule. If the Schedule is shorter than a run, the first ready actor is chosen
+// for the rest of steps. Run returns the full schedule and the history of the run.
+func Run(s Scenario, schedule Schedule) (Schedule, History, error) {
+	return run(s, func(step int, ready []int) int {
+		if step < len(schedule) {
+			for _, idx := range ready {
+				if idx == schedule[step] {
+					return idx
+				}
+			}
+		}
+		return ready[0]
+	})
+}
+
+// Sample runs a Scenario with n random schedules. Schedules are defined by seeds: seed, seed+1, ..., seed+n-1.
+// It returns a Failure for the first failed run.
+func Sample(s Scenario, seed int64, n int) error {
+	for i := int64(0); i < int64(n); i += 1 {
+		var rnd = rand.New(rand.NewSource(seed + i))
+		schedule, h, err := run(s, func(_ int, ready []int) int {
+			return ready[rnd.Intn(len(ready))]
+		})
+		if err != nil {
+			return &Failure{Seed: seed + i, Schedule: schedule, History: h, Err: err}
+		}
+	}
+	return nil
+}
+
+// Enumerate runs a Scenario with all possible schedules, but not more than a given limit of runs. It returns
+// a number of runs and a Failure for the first failed run.
+func Enumerate(s Scenario, limit int) (int, error) {
+	// choices is a stack of chosen positions in a list of ready actors and numbers of ready actors at each step.
+	type choice struct {
+		pos, n int
+	}
+	var choices []choice
+	for runs := 0; runs < limit; {
+		var prefix = len(choices)
+		schedule, h, err := run(s, func(step int, ready []int) int {
+			if step < prefix {
+				return ready[choices[step].pos]
+			}
+			choices = append(choices, choice{pos: 0, n: len(ready)})
+			return ready[0]
+		})
+		runs += 1
+		if err != nil {
+			return runs, &Failure{Schedule: schedule, History: h, Err: err}
+		}
+		// Backtrack to the latest step which has an unexplored choice.
+		for len(choices) > 0 && choices[len(choices)-1].pos+1 >= choices[len(choices)-1].n {
+			choices = choices[:len(choices)-1]
+		}
+		if len(choices) == 0 {
+			return runs, nil
+		}
+		choices[len(choices)-1].pos += 1
+	}
+	return limit, nil
+}
+
+// actor struct represents a state of an actor in a run.
+type actor struct {
+	ops     Actor
+	next    chan struct{}
+	step    int
+	start   int // start is a sequential number of the start of the current operation.
+	running bool
+	waiting bool // waiting defines that the next operation waits for a ready-channel of a sender.
+}
+
+// wait updates a waiting state of the actor for the next operation of a given Plexus.
+func (a *actor) wait(plx *plexus.Plexus) {
+	a.waiting = a.step < len(a.ops) && a.ops[a.step].Kind == KindSendReady && readyChan(plx, a.ops[a.step].Name) != nil
+}
+
+// run runs a Scenario. A given function chooses an actor for each step from a list of ready actors.
+func run(s Scenario, choose func(step int, ready []int) int) (Schedule, History, error) {
+	var (
+		plx      = s.New()
+		actors   = make([]*actor, 0, len(s.Actors))
+		done     = make(chan Event)
+		released = make(chan int, len(s.Actors))
+		schedule Schedule
+		history  History
+		running  int
+	)
+	for i, ops := range s.Actors {
+		var a = &actor{ops: ops, next: make(chan struct{})}
+		a.wait(plx)
+		actors = append(actors, a)
+		go func(i int, a *actor) {
+			for step, op := range a.ops {
+				if op.Kind == KindSendReady {
+					if ch := readyChan(plx, op.Name); ch != nil {
+						<-ch
+						released <- i
+					}
+				}
+				<-a.next
+				var ev = perform(plx, op)
+				ev.Actor, ev.Step = i, step
+				done <- ev
+			}
+		}(i, a)
+	}
+	var st = &state{
+		plx:      plx,
+		actors:   actors,
+		done:     done,
+		released: released,
+		running:  &running,
+		finish: func(ev Event) {
+			var a = actors[ev.Actor]
+			ev.Start = a.start
+			a.running = false
+			a.step += 1
+			a.wait(plx)
+			running -= 1
+			history = append(history, ev)
+		},
+	}
+	var starts int
+	for {
+		var ready []int
+		for i, a := range actors {
+			if !a.running && !a.waiting && a.step < len(a.ops) {
+				ready = append(ready, i)
+			}
+		}
+		if len(ready) == 0 {
+			break
+		}
+		var idx = choose(len(schedule), ready)
+		schedule = append(schedule, idx)
+		starts += 1
+		actors[idx].start = starts
+		actors[idx].running = true
+		running += 1
+		actors[idx].next <- struct{}{}
+		if err := st.quiesce(); err != nil {
+			return schedule, history, err
+		}
+	}
+	// All running operations are blocked. Close the Plexus to release them.
+	if running > 0 {
+		if !plx.Stats().Closed {
+			plx.Close()
+		}
+		for running > 0 {
+			st.finish(<-done)
+		}
+	}
+	if s.Check == nil {
+		return schedule, history, nil
+	}
+	return schedule, history, s.Check(history)
+}
+
+// state struct represents a state of a run which is checked for quiescence.
+type state struct {
+	plx      *plexus.Plexus
+	actors   []*actor
+	done     <-chan Event
+	released <-chan int
+	running  *int
+	finish   func(Event)
+}
+
+// snapshot struct represents a state of a Plexus which is checked for quiescence.
+type snapshot struct {
+	queued   int  // queued is a number of blocked calls.
+	releases bool // releases defines that ready-channels of waiting senders are released.
+}
+
+// quiesce waits till each running operation is either finished or blocked in a queue of a Plexus, and each waiting
+// SendReady operation is either released or can not be released before the next operation is started. Finished
+// operations are passed to a finish function, which decrements a number of running operations.
+func (st *state) quiesce() error {
+	var deadline = time.Now().Add(QuiescenceTimeout)
+	for {
+		// Take all finished operations and released senders.
+		for drained := false; !drained; {
+			select {
+			case ev := <-st.done:
+				st.finish(ev)
+			case idx := <-st.released:
+				st.actors[idx].waiting = false
+			default:
+				drained = true
+			}
+		}
+		// A release of ready-channels blocks the general lock of a Plexus till all senders take them, so the state is
+		// probed in a separate goroutine.
+		var probed = make(chan snapshot, 1)
+		go func() {
+			probed <- probe(st.plx)
+		}()
+		var snap snapshot
+		select {
+		case snap = <-probed:
+		case <-time.After(time.Until(deadline)):
+			return fmt.Errorf("plexus is locked by %d running operations: %w", *st.running, ErrorNotQuiescent)
+		}
+		if *st.running == snap.queued && (!snap.releases || st.waiting() == 0) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d operations are running, %d are queued: %w", *st.running, snap.queued,
+				ErrorNotQuiescent)
+		}
+		time.Sleep(10 * time.Microsecond)
+	}
+}
+
+// waiting returns a number of actors which wait for ready-channels.
+func (st *state) waiting() int {
+	var result int
+	for _, a := range st.actors {
+		if a.waiting {
+			result += 1
+		}
+	}
+	return result
+}
+
+// probe returns a snapshot of a state of a Plexus. Ready-channels are released, if the Plexus is closed or all
+// required receivers are blocked. A round of a Plexus with selectable senders is not completed without senders which
+// wait for ready-channels, so receivers stay blocked till the released senders send.
+func probe(plx *plexus.Plexus) snapshot {
+	var (
+		snap      = snapshot{queued: queued(plx)}
+		receivers = map[string]bool{}
+	)
+	if plx.Stats().Closed {
+		snap.releases = true
+		return snap
+	}
+	for _, w := range plx.Waiting() {
+		if w.Kind == "receiver" {
+			receivers[w.Name] = true
+		}
+	}
+	snap.releases = true
+	for _, p := range plx.Participants() {
+		if p.Kind == "receiver" && !p.Excluded && !receivers[p.Name] {
+			snap.releases = false
+		}
+	}
+	return snap
+}
+
+// queued returns a number of blocked calls of a Plexus.
+func queued(plx *plexus.Plexus) int {
+	var result int
+	for _, w := range plx.Waiting() {
+		result += w.Queued
+	}
+	return result
+}
+
+// readyChan returns a ready-channel of a given sender. It returns nil, if the Plexus does not have selectable senders
+// or the sender does not exist, so a SendReady operation fails without waiting.
+func readyChan(plx *plexus.Plexus, name string) (ch <-chan struct{}) {
+	defer func() {
+		if recover() != nil {
+			ch = nil
+		}
+	}()
+	return plx.ReadySend(name)
+}
+
+// perform executes an operation on a Plexus. A panic of the operation is recovered and returned in the Event.
+// A ready-channel of a SendReady operation is taken before the operation is started.
+func perform(plx *plexus.Plexus, op Op) (ev Event) {
+	ev.Op = op
+	ev.Begin = time.Now()
+	defer func() {
+		ev.End = time.Now()
+		if r := recover(); r != nil {
+			ev.OK = false
+			ev.Panic = r
+		}
+	}()
+	switch op.Kind {
+	case KindSend:
+		plx.Send(op.Name, op.Value)
+		ev.OK = true
+	case KindRecv:
+		ev.Value, ev.OK = plx.Recv(op.Name)
+	case KindSendReady:
+		plx.ReadySend(op.Name)
+		plx.Send(op.Name, op.Value)
+		ev.OK = true
+	case KindClose:
+		plx.Close()
+		ev.OK = true
+	}
+	return ev
+}
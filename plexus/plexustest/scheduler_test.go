@@ -0,0 +1,272 @@
+package plexustest_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alxmsl/prmtvs/plexus"
+	"github.com/alxmsl/prmtvs/plexus/plexustest"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type SchedulerSuite struct{}
+
+var (
+	_ = Suite(&SchedulerSuite{})
+)
+
+func newSsSr() *plexus.Plexus {
+	return plexus.NewPlexus(plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1))
+}
+
+func newMsMr() *plexus.Plexus {
+	return plexus.NewPlexus(plexus.WithReceiversNumber(2), plexus.WithSendersNumber(2))
+}
+
+// checkDelivery checks that each successfully sent value is received exactly once, in a FIFO order, and nothing is
+// received by operations started after the plexus is closed.
+func checkDelivery(h plexustest.History) error {
+	var (
+		sent, recv []any
+		closed     = -1
+	)
+	for _, ev := range h {
+		if ev.Op.Kind == plexustest.KindClose && ev.OK {
+			closed = ev.Start
+		}
+	}
+	for _, ev := range h {
+		switch {
+		case (ev.Op.Kind == plexustest.KindSend || ev.Op.Kind == plexustest.KindSendReady) && ev.OK:
+			sent = append(sent, ev.Op.Value)
+		case ev.Op.Kind == plexustest.KindRecv && ev.OK:
+			if closed >= 0 && ev.Start > closed {
+				return fmt.Errorf("%s receives %v after close", ev.Op, ev.Value)
+			}
+			recv = append(recv, ev.Value)
+		}
+	}
+	if len(sent) != len(recv) {
+		return fmt.Errorf("sent %v, received %v", sent, recv)
+	}
+	for i := range recv {
+		if recv[i] != i+1 {
+			return fmt.Errorf("received %v out of order", recv)
+		}
+	}
+	return nil
+}
+
+// TestEnumerateSsSr checks all interleavings of a sender, a receiver and a closer of the SsSr plexus.
+func (s *SchedulerSuite) TestEnumerateSsSr(c *C) {
+	var scenario = plexustest.Scenario{
+		New: newSsSr,
+		Actors: []plexustest.Actor{
+			{plexustest.Send("sender_0", 1), plexustest.Send("sender_0", 2)},
+			{plexustest.Recv("receiver_0"), plexustest.Recv("receiver_0")},
+			{plexustest.Close()},
+		},
+		Check: func(h plexustest.History) error {
+			if err := plexustest.CheckHistory(h.Calls()); err != nil {
+				return err
+			}
+			return checkDelivery(h)
+		},
+	}
+	runs, err := plexustest.Enumerate(scenario, 1000)
+	c.Assert(err, IsNil)
+	c.Assert(runs > 1, Equals, true)
+	c.Assert(runs < 1000, Equals, true)
+}
+
+// TestSampleMsMr checks random interleavings of the MsMr plexus.
+func (s *SchedulerSuite) TestSampleMsMr(c *C) {
+	var scenario = plexustest.Scenario{
+		New: newMsMr,
+		Actors: []plexustest.Actor{
+			{plexustest.Send("sender_0", plexus.Counter(1)), plexustest.Send("sender_0", plexus.Counter(1))},
+			{plexustest.Send("sender_1", plexus.Counter(2)), plexustest.Send("sender_1", plexus.Counter(2))},
+			{plexustest.Recv("receiver_0"), plexustest.Recv("receiver_0")},
+			{plexustest.Recv("receiver_1"), plexustest.Recv("receiver_1")},
+			{plexustest.Close()},
+		},
+		Check: func(h plexustest.History) error {
+			if err := plexustest.CheckHistory(h.Calls()); err != nil {
+				return err
+			}
+			for _, ev := range h {
+				if ev.Op.Kind == plexustest.KindRecv && ev.OK && ev.Value != plexus.Counter(3) {
+					return fmt.Errorf("%s receives %v", ev.Op, ev.Value)
+				}
+			}
+			return nil
+		},
+	}
+	c.Assert(plexustest.Sample(scenario, 1, 50), IsNil)
+}
+
+// TestBlockedAtEnd checks that operations blocked at the end of a run are released by closing the plexus.
+func (s *SchedulerSuite) TestBlockedAtEnd(c *C) {
+	var scenario = plexustest.Scenario{
+		New: newSsSr,
+		Actors: []plexustest.Actor{
+			{plexustest.Send("sender_0", 1), plexustest.Send("sender_0", 2)},
+			{plexustest.Recv("receiver_0")},
+		},
+	}
+	schedule, h, err := plexustest.Run(scenario, plexustest.Schedule{0, 1, 0})
+	c.Assert(err, IsNil)
+	c.Assert(schedule, DeepEquals, plexustest.Schedule{0, 1, 0})
+	c.Assert(h, HasLen, 3)
+	c.Assert(h[2].Op, Equals, plexustest.Send("sender_0", 2))
+	c.Assert(h[2].OK, Equals, false)
+	c.Assert(h[2].Panic, NotNil)
+}
+
+// TestFailure checks that a failed run is reported with a seed and a schedule, which reproduces it.
+func (s *SchedulerSuite) TestFailure(c *C) {
+	var errExpected = errors.New("receiver is first")
+	var scenario = plexustest.Scenario{
+		New: newSsSr,
+		Actors: []plexustest.Actor{
+			{plexustest.Send("sender_0", 1)},
+			{plexustest.Recv("receiver_0")},
+		},
+		Check: func(h plexustest.History) error {
+			if h[0].Op.Kind == plexustest.KindRecv {
+				return errExpected
+			}
+			return nil
+		},
+	}
+	var err = plexustest.Sample(scenario, 1, 100)
+	var f *plexustest.Failure
+	c.Assert(errors.As(err, &f), Equals, true)
+	c.Assert(errors.Is(err, errExpected), Equals, true)
+	c.Assert(err, ErrorMatches, fmt.Sprintf("seed %d, schedule \\[.*\\]: receiver is first", f.Seed))
+
+	_, _, err = plexustest.Run(scenario, f.Schedule)
+	c.Assert(err, Equals, errExpected)
+}
+
+// TestCloseBlocked checks that a close releases blocked senders and receivers of an incomplete round, and operations
+// started after the close fail.
+func (s *SchedulerSuite) TestCloseBlocked(c *C) {
+	var scenario = plexustest.Scenario{
+		New: newMsMr,
+		Actors: []plexustest.Actor{
+			{plexustest.Send("sender_0", plexus.Counter(1))},
+			{plexustest.Recv("receiver_0")},
+			{plexustest.Close()},
+			{plexustest.Send("sender_1", plexus.Counter(2))},
+		},
+	}
+	_, h, err := plexustest.Run(scenario, plexustest.Schedule{0, 1, 2, 3})
+	c.Assert(err, IsNil)
+	c.Assert(h, HasLen, 4)
+	for _, ev := range h {
+		switch ev.Op.Kind {
+		case plexustest.KindSend:
+			c.Assert(ev.OK, Equals, false)
+			c.Assert(ev.Panic, Equals, plexus.ErrorSendToClosedPlexus)
+		case plexustest.KindRecv:
+			c.Assert(ev.OK, Equals, false)
+			c.Assert(ev.Panic, IsNil)
+		}
+	}
+	c.Assert(plexustest.CheckHistory(h.Calls()), IsNil)
+}
+
+// TestCloseAfterRound checks that a close after a completed round does not affect results of the round.
+func (s *SchedulerSuite) TestCloseAfterRound(c *C) {
+	var scenario = plexustest.Scenario{
+		New: newMsMr,
+		Actors: []plexustest.Actor{
+			{plexustest.Send("sender_0", plexus.Counter(1))},
+			{plexustest.Send("sender_1", plexus.Counter(2))},
+			{plexustest.Recv("receiver_0")},
+			{plexustest.Recv("receiver_1")},
+			{plexustest.Close()},
+		},
+	}
+	_, h, err := plexustest.Run(scenario, plexustest.Schedule{2, 0, 3, 1, 4})
+	c.Assert(err, IsNil)
+	c.Assert(h, HasLen, 5)
+	for _, ev := range h {
+		c.Assert(ev.OK, Equals, true)
+		if ev.Op.Kind == plexustest.KindRecv {
+			c.Assert(ev.Value, Equals, plexus.Counter(3))
+		}
+	}
+	c.Assert(h[4].Op, Equals, plexustest.Close())
+	c.Assert(plexustest.CheckHistory(h.Calls()), IsNil)
+}
+
+// TestEnumerateSendReady checks all interleavings of a selectable sender, a receiver and a closer of the SsSr plexus.
+func (s *SchedulerSuite) TestEnumerateSendReady(c *C) {
+	var scenario = plexustest.Scenario{
+		New: func() *plexus.Plexus {
+			return plexus.NewPlexus(plexus.WithReceiversNumber(1), plexus.WithSendersNumber(1),
+				plexus.WithSelectableSenders())
+		},
+		Actors: []plexustest.Actor{
+			{plexustest.SendReady("sender_0", 1), plexustest.SendReady("sender_0", 2)},
+			{plexustest.Recv("receiver_0"), plexustest.Recv("receiver_0")},
+			{plexustest.Close()},
+		},
+		Check: func(h plexustest.History) error {
+			if err := plexustest.CheckHistory(h.Calls()); err != nil {
+				return err
+			}
+			return checkDelivery(h)
+		},
+	}
+	runs, err := plexustest.Enumerate(scenario, 1000)
+	c.Assert(err, IsNil)
+	c.Assert(runs > 1, Equals, true)
+	c.Assert(runs < 1000, Equals, true)
+}
+
+// TestSendReadyOrder checks that a selectable sender is scheduled only after all receivers are blocked.
+func (s *SchedulerSuite) TestSendReadyOrder(c *C) {
+	var scenario = plexustest.Scenario{
+		New: func() *plexus.Plexus {
+			return plexus.NewPlexus(plexus.WithReceiversNumber(2), plexus.WithSendersNumber(1),
+				plexus.WithSelectableSenders())
+		},
+		Actors: []plexustest.Actor{
+			{plexustest.SendReady("sender_0", plexus.Counter(1))},
+			{plexustest.Recv("receiver_0")},
+			{plexustest.Recv("receiver_1")},
+		},
+	}
+	schedule, h, err := plexustest.Run(scenario, plexustest.Schedule{0, 0, 0})
+	c.Assert(err, IsNil)
+	c.Assert(schedule, DeepEquals, plexustest.Schedule{1, 2, 0})
+	c.Assert(h, HasLen, 3)
+	for _, ev := range h {
+		c.Assert(ev.OK, Equals, true)
+	}
+	c.Assert(plexustest.CheckHistory(h.Calls()), IsNil)
+}
+
+// TestSendReadyNotSelectable checks that a SendReady operation fails on a plexus without selectable senders.
+func (s *SchedulerSuite) TestSendReadyNotSelectable(c *C) {
+	var scenario = plexustest.Scenario{
+		New: newSsSr,
+		Actors: []plexustest.Actor{
+			{plexustest.SendReady("sender_0", 1)},
+		},
+	}
+	_, h, err := plexustest.Run(scenario, nil)
+	c.Assert(err, IsNil)
+	c.Assert(h, HasLen, 1)
+	c.Assert(h[0].OK, Equals, false)
+	c.Assert(h[0].Panic, NotNil)
+}
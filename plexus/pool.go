@@ -0,0 +1,51 @@
+package plexus
+
+import (
+	"sync"
+)
+
+// chanPool is a pool of channels for blocked calls. A channel is taken by a call and it is put back by the same call
+// after it is released by a round. Channels closed by Plexus.Close are not put back. A channel keeps a single value,
+// so a round never blocks on a call which has not started to wait yet, including the call which completes the round.
+var chanPool = sync.Pool{
+	New: func() any {
+		return make(chan any, 1)
+	},
+}
+
+// getChan returns a channel from the pool.
+func getChan() chan any {
+	return chanPool.Get().(chan any)
+}
+
+// putChan puts a channel back into the pool.
+func putChan(ch chan any) {
+	chanPool.Put(ch)
+}
+
+// roundBuf struct represents reusable buffers for waiters of senders and receivers taken by a round.
+type roundBuf struct {
+	senders   []waiter
+	receivers []waiter
+}
+
+// roundPool is a pool of buffers for rounds. A round takes buffers outside the general lock of a Plexus, so
+// simultaneous rounds use different buffers.
+var roundPool = sync.Pool{
+	New: func() any {
+		return &roundBuf{}
+	},
+}
+
+// getRoundBuf returns empty buffers from the pool.
+func getRoundBuf() *roundBuf {
+	return roundPool.Get().(*roundBuf)
+}
+
+// putRoundBuf clears buffers and puts them back into the pool.
+func putRoundBuf(buf *roundBuf) {
+	clear(buf.senders)
+	clear(buf.receivers)
+	buf.senders, buf.receivers = buf.senders[:0], buf.receivers[:0]
+	roundPool.Put(buf)
+}
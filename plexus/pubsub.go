@@ -0,0 +1,275 @@
+package plexus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// topicPublisher is a name of a sender of a topic plexus. All publishers of a topic share the sender.
+const topicPublisher = "publisher"
+
+// PubSub struct represents a publish-subscribe layer on top of plexuses. Each topic is backed by an SsMr Plexus with
+// a single sender for publishers and a receiver for each subscriber. Receivers of a topic are leased by subscriptions,
+// unused receivers are removed from the round requirement. A topic is created by the first subscription, and it is
+// removed when the last subscription is cancelled.
+//
+// Each subscription has an own goroutine, which passes values of the topic into a buffer of the subscription. A value
+// is dropped for a subscription, if the buffer stays full for a delivery timeout of the subscription. So a slow
+// subscriber delays publishers of its topic by the timeout at most, and it does not delay other topics.
+type PubSub struct {
+	capacity int
+
+	lock   sync.Mutex
+	closed bool
+	topics map[string]*topic
+}
+
+// topic struct represents a Plexus of a topic.
+type topic struct {
+	name string
+	plx  *Plexus
+}
+
+// Subscription struct represents a subscription to a topic with a buffer of values.
+type Subscription struct {
+	ps      *PubSub
+	topic   *topic
+	lease   *ReceiverLease
+	ch      chan any
+	timeout time.Duration
+	dropped atomic.Uint64
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewPubSub creates a PubSub object with a given maximal number of simultaneous subscriptions to a single topic.
+// It returns an error wrapping ErrorInvalidOptions if the capacity is not positive.
+func NewPubSub(capacity int) (*PubSub, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("%w: invalid pubsub capacity %d", ErrorInvalidOptions, capacity)
+	}
+	return &PubSub{
+		capacity: capacity,
+		topics:   make(map[string]*topic),
+	}, nil
+}
+
+// Publish passes a value to all current subscribers of a topic with a given name. It blocks till goroutines of all
+// subscriptions take the value. A value which is published to a topic without subscribers is dropped. It returns
+// an error wrapping ErrorPubSubClosed if the PubSub is closed.
+func (ps *PubSub) Publish(name string, value any) error {
+	ps.lock.Lock()
+	if ps.closed {
+		ps.lock.Unlock()
+		return fmt.Errorf("can not publish to '%s': %w", name, ErrorPubSubClosed)
+	}
+	t, ok := ps.topics[name]
+	ps.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	// The topic Plexus is closed, if the last subscription is cancelled or the PubSub is closed during the send.
+	if t.plx.trySend(topicPublisher, value) != nil && ps.isClosed() {
+		return fmt.Errorf("can not publish to '%s': %w", name, ErrorPubSubClosed)
+	}
+	return nil
+}
+
+// Subscribe subscribes to a topic with a given name. A subscription takes values published after the subscription.
+// Values are buffered in a channel of a given non-negative size. A value is dropped, if the buffer is full for a given
+// timeout. A zero timeout drops a value immediately. It returns an error wrapping ErrorNoFreeParticipant if the topic
+// has the maximal number of subscriptions, or ErrorPubSubClosed if the PubSub is closed.
+func (ps *PubSub) Subscribe(name string, buffer int, timeout time.Duration) (*Subscription, error) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if ps.closed {
+		return nil, fmt.Errorf("can not subscribe to '%s': %w", name, ErrorPubSubClosed)
+	}
+	t, ok := ps.topics[name]
+	if !ok {
+		var err error
+		t, err = ps.newTopic(name)
+		if err != nil {
+			return nil, fmt.Errorf("can not subscribe to '%s': %w", name, err)
+		}
+	}
+	l, err := t.plx.AcquireReceiver()
+	if err != nil {
+		return nil, fmt.Errorf("can not subscribe to '%s': %w", name, err)
+	}
+	t.plx.lock.Lock()
+	t.plx.recvq.include(l.r.p)
+	t.plx.lock.Unlock()
+	ps.topics[name] = t
+
+	var s = &Subscription{
+		ps:      ps,
+		topic:   t,
+		lease:   l,
+		ch:      make(chan any, buffer),
+		timeout: timeout,
+		done:    make(chan struct{}),
+	}
+	go s.forward()
+	return s, nil
+}
+
+// newTopic creates a topic with a given name. All receivers of the topic are removed from the round requirement.
+func (ps *PubSub) newTopic(name string) (*topic, error) {
+	plx, err := NewPlexusE(WithName(name), WithSenders(topicPublisher), WithReceiversNumber(ps.capacity))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plx.recvq.pm {
+		plx.recvq.exclude(p, 0)
+	}
+	return &topic{name: name, plx: plx}, nil
+}
+
+// Topics returns names of all topics with subscriptions in a sorted order.
+func (ps *PubSub) Topics() []string {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	var names = make([]string, 0, len(ps.topics))
+	for name := range ps.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close closes plexuses of all topics. Channels of all subscriptions are closed.
+func (ps *PubSub) Close() {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if ps.closed {
+		panic(ErrorPubSubClosed)
+	}
+	ps.closed = true
+	for name, t := range ps.topics {
+		t.plx.tryClose()
+		delete(ps.topics, name)
+	}
+}
+
+// isClosed returns TRUE if the PubSub is closed.
+func (ps *PubSub) isClosed() bool {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	return ps.closed
+}
+
+// Topic returns a name of the subscribed topic.
+func (s *Subscription) Topic() string {
+	return s.topic.name
+}
+
+// C returns a channel of values of the subscription. The channel is closed on Unsubscribe or when the PubSub is
+// closed. Buffered values are readable after that.
+func (s *Subscription) C() <-chan any {
+	return s.ch
+}
+
+// Dropped returns a number of values which are dropped, because the buffer of the subscription is full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe cancels the subscription. The channel of the subscription is closed, when the goroutine of
+// the subscription leaves the topic. It is safe to call Unsubscribe several times.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		// The subscription is cancelled in the general lock of the topic Plexus, so the goroutine of the subscription
+		// does not leave the topic before its waiting call is released.
+		var plx = s.topic.plx
+		plx.lock.Lock()
+		defer plx.lock.Unlock()
+		close(s.done)
+		// Release the goroutine, if it waits for a value of the topic. Otherwise, it leaves the topic after
+		// the current delivery or the next round.
+		if p := s.lease.r.p; p.q.len() > 0 && !plx.closed {
+			close(plx.recvq.pop(p.q).ch)
+		}
+	})
+}
+
+// cancelled returns TRUE if the subscription is cancelled.
+func (s *Subscription) cancelled() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// forward passes values of the topic into the buffer of the subscription till the subscription is cancelled or
+// the topic Plexus is closed.
+func (s *Subscription) forward() {
+	defer close(s.ch)
+	for !s.cancelled() {
+		v, ok := s.lease.Recv()
+		if s.cancelled() {
+			break
+		}
+		if !ok {
+			return
+		}
+		s.deliver(v)
+	}
+	s.leave()
+}
+
+// deliver passes a value into the buffer of the subscription. The value is dropped, if the buffer is full for
+// the timeout of the subscription or the subscription is cancelled.
+func (s *Subscription) deliver(v any) {
+	select {
+	case s.ch <- v:
+		return
+	default:
+	}
+	if s.timeout > 0 {
+		var t = time.NewTimer(s.timeout)
+		defer t.Stop()
+		select {
+		case s.ch <- v:
+			return
+		case <-s.done:
+		case <-t.C:
+		}
+	}
+	s.dropped.Add(1)
+}
+
+// leave removes the receiver of the subscription from the round requirement of the topic and releases it. A round of
+// other subscribers is completed, if they are waiting. The topic is removed, if it does not have subscriptions.
+func (s *Subscription) leave() {
+	var (
+		ps  = s.ps
+		plx = s.topic.plx
+	)
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	// The last subscription closes the topic, so publishers never send to the topic without receivers.
+	plx.lock.Lock()
+	var last = plx.recvq.required() == 1
+	if !last {
+		plx.recvq.exclude(s.lease.r.p, 0)
+	}
+	plx.lock.Unlock()
+	s.lease.Release()
+
+	if last {
+		if ps.topics[s.topic.name] == s.topic {
+			delete(ps.topics, s.topic.name)
+		}
+		plx.tryClose()
+		return
+	}
+	for plx.tryRound("") {
+	}
+}
@@ -2,17 +2,66 @@ package plexus
 
 import (
 	"fmt"
+	"sort"
 	"sync"
-
-	"gopkg.in/eapache/queue.v1"
+	"sync/atomic"
+	"time"
 )
 
+// waiter struct represents an item of a queue: a channel of a blocked call, a value of a blocked sender and a time
+// when the call is blocked. A blocked call waits for a value from the channel: a receiver takes a result of a round,
+// a sender takes nil when its value is taken by a round. The channel is closed, if the Plexus is closed.
+type waiter struct {
+	ch    chan any
+	value any
+	since time.Time
+}
+
+// participant struct represents a queue and a meter of a single sender or receiver. It caches lookups of a name for
+// Plexus calls and handles.
+//
+// The queue is a shard of the Plexus state: a call adds its waiter in the lock of the participant and the shared
+// general lock, so calls of different participants do not wait for each other. A round takes waiters from queues in
+// the exclusive general lock, which does not need locks of participants.
+type participant struct {
+	name  string
+	lock  sync.Mutex // lock guards the queue for calls in the shared general lock.
+	q     *ring
+	m     *meter
+	limit *bucket // limit is a rate limit of the participant, if it is defined.
+
+	last     atomic.Int64 // last is a time of the last call or heartbeat in nanoseconds.
+	dead     atomic.Bool  // dead defines that the participant has missed heartbeats.
+	excluded atomic.Bool  // excluded defines that the participant is removed from the round requirement.
+}
+
+// beat records a heartbeat of the participant at a given time.
+func (p *participant) beat(now time.Time) {
+	p.last.Store(now.UnixNano())
+}
+
 // queues struct represents a named set of queue of the fixed capacity.
-// Each item in the queue is a channel.
+// Each item in the queue is a waiter.
 type queues struct {
 	cap  int
 	lock sync.Mutex
-	qm   map[string]*queue.Queue
+	qm   map[string]*ring
+	mm   map[string]*meter // mm is a named set of meters of participants.
+	// pm is a named set of participants. The set is not changed after a Plexus is created, so it is read without locks.
+	pm map[string]*participant
+	// qs is a list of queues in order of addition. Rounds iterate the list instead of the map.
+	qs []*ring
+	// names is a list of names of queues in order of addition.
+	names []string
+	// leased is a set of names which are leased by Plexus.AcquireSender or Plexus.AcquireReceiver.
+	leased map[string]bool
+	// excluded is a number of participants which are removed from the round requirement. It is changed in
+	// the exclusive general lock only.
+	excluded int
+	// occupied is a number of queues which contain at least one channel. Counter is updated on each push and pop,
+	// so a check of a round readiness does not iterate queues. Calls update it concurrently in the shared general
+	// lock, so the call which adds the last required waiter observes the full occupancy.
+	occupied atomic.Int64
 }
 
 // newQueues creates a queues object with a given capacity.
@@ -20,7 +69,11 @@ func newQueues(cap int) *queues {
 	return &queues{
 		cap:  cap,
 		lock: sync.Mutex{},
-		qm:   make(map[string]*queue.Queue, cap),
+		qm:   make(map[string]*ring, cap),
+		mm:   make(map[string]*meter, cap),
+		pm:   make(map[string]*participant, cap),
+
+		leased: make(map[string]bool, cap),
 	}
 }
 
@@ -35,78 +88,179 @@ func (qm *queues) add(name string) {
 	if len(qm.qm) >= qm.cap {
 		panic(ErrorQueuesIsFull)
 	}
-	qm.qm[name] = queue.New()
+	var q, m = &ring{}, newMeter()
+	qm.qm[name] = q
+	qm.mm[name] = m
+	qm.pm[name] = &participant{name: name, q: q, m: m}
+	qm.pm[name].beat(time.Now())
+	qm.qs = append(qm.qs, q)
+	qm.names = append(qm.names, name)
 }
 
-// close closes all channels stored in queues.
-func (qm *queues) close() {
-	for _, q := range qm.qm {
-		for q.Length() > 0 {
-			var ch = q.Remove().(chan any)
-			close(ch)
+// lease marks the first free name in order of addition as leased, and it returns the name. It returns FALSE if all
+// names are leased.
+func (qm *queues) lease() (string, bool) {
+	for _, name := range qm.names {
+		if !qm.leased[name] {
+			qm.leased[name] = true
+			return name, true
 		}
 	}
+	return "", false
 }
 
-// dequeue returns a subset of channels. Subset contains one channel from each named queue.
-func (qm *queues) dequeue() []chan any {
-	if len(qm.qm) != qm.cap {
-		panic(ErrorQueuesIsNotDefined)
+// release marks a given name as free.
+func (qm *queues) release(name string) {
+	delete(qm.leased, name)
+}
+
+// participant returns a participant with a given name. It returns an error if there is no queue with a given name.
+func (qm *queues) participant(name, kind string) (*participant, error) {
+	p, ok := qm.pm[name]
+	if !ok {
+		return nil, fmt.Errorf("can not find %s '%s': %w", kind, name, ErrorQueueDoesNotExist)
+	}
+	return p, nil
+}
+
+// stats returns a snapshot of counters of all participants in a sorted order of names.
+func (qm *queues) stats(kind string) []ParticipantStats {
+	var names = make([]string, 0, len(qm.mm))
+	for name := range qm.mm {
+		names = append(names, name)
 	}
-	var result = make([]chan any, 0, qm.cap)
-	for _, q := range qm.qm {
-		var ch = q.Remove().(chan any)
-		result = append(result, ch)
+	sort.Strings(names)
+	var result = make([]ParticipantStats, 0, len(names))
+	for _, name := range names {
+		var stats = qm.mm[name].stats(name, kind)
+		stats.Dead = qm.pm[name].dead.Load()
+		stats.Excluded = qm.pm[name].excluded.Load()
+		result = append(result, stats)
 	}
 	return result
 }
 
-// dequeue returns a subset of channels.
-// Subset contains one channel from each named queue except the given name.
-func (qm *queues) dequeueExcept(name string) []chan any {
+// required returns a number of participants which are required for a round.
+func (qm *queues) required() int {
+	return qm.cap - qm.excluded
+}
+
+// exclude removes a given participant from the round requirement. A number of required participants is not reduced
+// below a given minimum. It returns TRUE if the participant is removed.
+func (qm *queues) exclude(p *participant, min int) bool {
+	if p.excluded.Load() || qm.required() <= min {
+		return false
+	}
+	p.excluded.Store(true)
+	qm.excluded += 1
+	return true
+}
+
+// include returns a given participant into the round requirement.
+func (qm *queues) include(p *participant) {
+	if p.excluded.Load() {
+		p.excluded.Store(false)
+		qm.excluded -= 1
+	}
+}
+
+// close closes all channels stored in queues. Blocked calls are released by closed channels.
+func (qm *queues) close() {
+	for _, q := range qm.qs {
+		for q.len() > 0 {
+			close(q.pop().ch)
+		}
+	}
+	qm.occupied.Store(0)
+}
+
+// dequeue appends a subset of waiters to a given buffer. Subset contains one waiter from each non-empty queue.
+// Queues of excluded participants are empty, so the subset contains waiters of all required participants. It must be
+// called in the exclusive general lock.
+func (qm *queues) dequeue(buf []waiter) []waiter {
 	if len(qm.qm) != qm.cap {
 		panic(ErrorQueuesIsNotDefined)
 	}
-	var result = make([]chan any, 0, qm.cap-1)
-	for k, q := range qm.qm {
-		if name == k {
+	for _, q := range qm.qs {
+		if q.len() == 0 {
 			continue
 		}
-		var ch = q.Remove().(chan any)
-		result = append(result, ch)
+		buf = append(buf, qm.pop(q))
 	}
-	return result
+	return buf
+}
+
+// enqueue adds a given channel into a queue of a given participant. The channel is blocked since a given time.
+// A value is a value of a blocked sender, it is nil for a blocked receiver. It returns a number of occupied queues
+// after the channel is added. It must be called in the lock of the participant.
+func (qm *queues) enqueue(p *participant, ch chan any, value any, since time.Time) int {
+	p.q.push(waiter{ch: ch, value: value, since: since})
+	if p.q.len() == 1 {
+		return int(qm.occupied.Add(1))
+	}
+	return int(qm.occupied.Load())
 }
 
-// enqueue adds a given channel into a queue with a given name.
-func (qm *queues) enqueue(name string, ch chan any) {
-	if _, ok := qm.qm[name]; !ok {
-		panic(fmt.Errorf("can not add channel to '%s': %w", name, ErrorQueueDoesNotExist))
+// pop removes the first waiter from a given queue and updates the occupied counter. It must be called in
+// the exclusive general lock.
+func (qm *queues) pop(q *ring) waiter {
+	var w = q.pop()
+	if q.len() == 0 {
+		qm.occupied.Add(-1)
 	}
-	qm.qm[name].Add(ch)
+	return w
 }
 
 // occupancy returns number of queue contains at least one channel.
 func (qm *queues) occupancy() int {
-	var result int
-	for _, q := range qm.qm {
-		if q.Length() > 0 {
-			result += 1
-		}
+	return int(qm.occupied.Load())
+}
+
+// head returns a number of waiters of the participant and a time since the oldest of them is blocked.
+func (p *participant) head() (int, time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.q.len() == 0 {
+		return 0, time.Time{}
 	}
-	return result
+	return p.q.len(), p.q.peek().since
 }
 
-// occupancy returns number of queue contains at least one channel. Queue with a given name is ignored.
-func (qm *queues) occupancyExcept(name string) int {
-	var result int
-	for k, q := range qm.qm {
-		if name == k {
+// lengths returns names of queues in a sorted order and numbers of channels in each of them.
+func (qm *queues) lengths() ([]string, []int) {
+	var names = make([]string, 0, len(qm.pm))
+	for name := range qm.pm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var result = make([]int, 0, len(names))
+	for _, name := range names {
+		n, _ := qm.pm[name].head()
+		result = append(result, n)
+	}
+	return names, result
+}
+
+// waiting returns waiters of all non-empty queues in a sorted order of names. Waiter contains a number of blocked
+// calls and a waiting time of the oldest call measured till a given time.
+func (qm *queues) waiting(kind string, now time.Time) []Waiter {
+	var names = make([]string, 0, len(qm.pm))
+	for name := range qm.pm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var result = make([]Waiter, 0, len(names))
+	for _, name := range names {
+		n, since := qm.pm[name].head()
+		if n == 0 {
 			continue
 		}
-		if q.Length() > 0 {
-			result += 1
-		}
+		result = append(result, Waiter{
+			Name:   name,
+			Kind:   kind,
+			Queued: n,
+			Waited: now.Sub(since),
+		})
 	}
 	return result
 }
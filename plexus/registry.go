@@ -0,0 +1,96 @@
+package plexus
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/alxmsl/prmtvs/skm"
+)
+
+// Registry struct represents a set of plexuses registered by their names. Names are kept in a sorted order.
+type Registry struct {
+	lock sync.RWMutex
+	skm  *skm.SKM
+}
+
+// NewRegistry creates an empty Registry object.
+func NewRegistry() *Registry {
+	return &Registry{
+		skm: skm.NewSortedKeyMap(),
+	}
+}
+
+// Register adds a given Plexus into the Registry under its name. The name is defined by WithName option.
+func (r *Registry) Register(plx *Plexus) error {
+	var name = plx.Name()
+	if name == "" {
+		return ErrorPlexusWithoutName
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.skm.Add(name, plx) {
+		return fmt.Errorf("can not register plexus '%s': %w", name, ErrorPlexusAlreadyRegistered)
+	}
+	return nil
+}
+
+// Lookup returns a registered Plexus by name.
+func (r *Registry) Lookup(name string) (*Plexus, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	v, ok := r.skm.GetByKey(name)
+	if !ok {
+		return nil, false
+	}
+	return v.(*Plexus), true
+}
+
+// Names returns names of all registered plexuses in a sorted order.
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var result = make([]string, 0, r.skm.Len())
+	r.skm.Over(func(_ int, key string, _ interface{}) bool {
+		result = append(result, key)
+		return true
+	})
+	return result
+}
+
+// Len returns a number of registered plexuses.
+func (r *Registry) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.skm.Len()
+}
+
+// Stats returns an iterator over stats of all registered plexuses in a sorted order of names.
+func (r *Registry) Stats() iter.Seq[Stats] {
+	return func(yield func(Stats) bool) {
+		for _, plx := range r.plexuses() {
+			if !yield(plx.Stats()) {
+				return
+			}
+		}
+	}
+}
+
+// Close closes all registered plexuses which are not closed yet.
+func (r *Registry) Close() {
+	for _, plx := range r.plexuses() {
+		plx.tryClose()
+	}
+}
+
+// plexuses returns a snapshot of all registered plexuses in a sorted order of names.
+func (r *Registry) plexuses() []*Plexus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var result = make([]*Plexus, 0, r.skm.Len())
+	r.skm.Over(func(_ int, _ string, value interface{}) bool {
+		result = append(result, value.(*Plexus))
+		return true
+	})
+	return result
+}
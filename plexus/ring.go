@@ -0,0 +1,47 @@
+package plexus
+
+// ring struct represents a FIFO queue of waiters based on a ring buffer. The buffer grows on demand and it is reused
+// afterwards, so a steady flow of waiters does not allocate memory.
+type ring struct {
+	buf  []waiter
+	head int
+	size int
+}
+
+// len returns a number of waiters in the ring.
+func (r *ring) len() int {
+	return r.size
+}
+
+// peek returns the oldest waiter in the ring. The ring must not be empty.
+func (r *ring) peek() waiter {
+	return r.buf[r.head]
+}
+
+// pop removes and returns the oldest waiter in the ring. The ring must not be empty.
+func (r *ring) pop() waiter {
+	var w = r.buf[r.head]
+	r.buf[r.head] = waiter{}
+	r.head = (r.head + 1) % len(r.buf)
+	r.size -= 1
+	return w
+}
+
+// push adds a waiter into the ring.
+func (r *ring) push(w waiter) {
+	if r.size == len(r.buf) {
+		r.grow()
+	}
+	r.buf[(r.head+r.size)%len(r.buf)] = w
+	r.size += 1
+}
+
+// grow doubles a capacity of the ring buffer.
+func (r *ring) grow() {
+	var buf = make([]waiter, max(4, 2*len(r.buf)))
+	for i := 0; i < r.size; i += 1 {
+		buf[i] = r.buf[(r.head+i)%len(r.buf)]
+	}
+	r.buf = buf
+	r.head = 0
+}
@@ -0,0 +1,154 @@
+package plexus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// gatherReceiver is a name of the gather plexus and its receiver.
+	gatherReceiver = "gather"
+	// scatterSender is a name of the scatter plexus and its sender.
+	scatterSender = "scatter"
+)
+
+// call struct represents a request of a ScatterGather call with a sequence number of the call.
+type call struct {
+	seq uint64
+	req any
+}
+
+// reply struct represents a response of a worker to a ScatterGather call. Replies of the same call are merged.
+type reply struct {
+	seq uint64
+	res Mergeable
+}
+
+// Merge merges responses of workers to the same call.
+func (r reply) Merge(m Mergeable) Mergeable {
+	var o = m.(reply)
+	if r.seq != o.seq {
+		panic(fmt.Errorf("can not merge replies to calls %d and %d: %w", r.seq, o.seq, ErrorUnknownState))
+	}
+	return reply{seq: r.seq, res: r.res.Merge(o.res)}
+}
+
+// ScatterGather struct represents a request-response helper on top of two plexuses. A request is sent to all workers
+// by the SsMr plexus, and responses of workers are merged by the MsSr plexus. Calls are correlated with responses by
+// sequence numbers, so concurrent callers do not mix responses.
+type ScatterGather struct {
+	scatter *Plexus
+	gather  *Plexus
+
+	lock    sync.Mutex
+	seq     uint64
+	pending map[uint64]chan Mergeable // pending is a set of channels of calls waiting for responses.
+}
+
+// NewScatterGather creates a ScatterGather object for workers with given names. It returns an error if names of
+// workers are not valid. See NewPlexusE for details.
+func NewScatterGather(workers ...string) (*ScatterGather, error) {
+	scatter, err := NewPlexusE(WithName(scatterSender), WithSenders(scatterSender), WithReceivers(workers...))
+	if err != nil {
+		return nil, fmt.Errorf("can not create scatter plexus: %w", err)
+	}
+	gather, err := NewPlexusE(WithName(gatherReceiver), WithSenders(workers...), WithReceivers(gatherReceiver))
+	if err != nil {
+		return nil, fmt.Errorf("can not create gather plexus: %w", err)
+	}
+	var sg = &ScatterGather{
+		scatter: scatter,
+		gather:  gather,
+		pending: make(map[uint64]chan Mergeable),
+	}
+	go sg.dispatch()
+	return sg, nil
+}
+
+// dispatch passes merged responses to waiting calls till the ScatterGather is closed.
+func (sg *ScatterGather) dispatch() {
+	for {
+		v, ok := sg.gather.Recv(gatherReceiver)
+		if !ok {
+			return
+		}
+		var r = v.(reply)
+		sg.lock.Lock()
+		ch, ok := sg.pending[r.seq]
+		delete(sg.pending, r.seq)
+		sg.lock.Unlock()
+		// The call is cancelled, if there is no waiting channel.
+		if ok {
+			ch <- r.res
+		}
+	}
+}
+
+// Call sends a given request to all workers and returns merged responses of workers. It returns an error of a given
+// context if the context is done before responses are merged. A cancelled request is still passed to workers, but
+// responses to it are dropped. Call returns an error wrapping ErrorCallClosed if the ScatterGather is closed.
+func (sg *ScatterGather) Call(ctx context.Context, req any) (Mergeable, error) {
+	var ch = make(chan Mergeable, 1)
+	sg.lock.Lock()
+	sg.seq += 1
+	var seq = sg.seq
+	sg.pending[seq] = ch
+	sg.lock.Unlock()
+
+	var sent = make(chan bool, 1)
+	go func() {
+		sent <- sg.scatter.trySend(scatterSender, call{seq: seq, req: req}) == nil
+	}()
+	select {
+	case res := <-ch:
+		return res, nil
+	case ok := <-sent:
+		if !ok {
+			sg.cancel(seq)
+			return nil, fmt.Errorf("can not call %d: %w", seq, ErrorCallClosed)
+		}
+	case <-ctx.Done():
+		sg.cancel(seq)
+		return nil, fmt.Errorf("can not call %d: %w", seq, ctx.Err())
+	}
+	select {
+	case res := <-ch:
+		return res, nil
+	case <-sg.gather.Done():
+		sg.cancel(seq)
+		return nil, fmt.Errorf("can not call %d: %w", seq, ErrorCallClosed)
+	case <-ctx.Done():
+		sg.cancel(seq)
+		return nil, fmt.Errorf("can not call %d: %w", seq, ctx.Err())
+	}
+}
+
+// cancel drops a waiting channel of a call with a given sequence number.
+func (sg *ScatterGather) cancel(seq uint64) {
+	sg.lock.Lock()
+	defer sg.lock.Unlock()
+	delete(sg.pending, seq)
+}
+
+// Serve handles requests by a worker with a given name till the ScatterGather is closed. Each request is passed to
+// a given handler, and the result of the handler is merged with results of other workers. The handler must not return
+// nil.
+func (sg *ScatterGather) Serve(name string, handler func(req any) Mergeable) {
+	for {
+		v, ok := sg.scatter.Recv(name)
+		if !ok {
+			return
+		}
+		var c = v.(call)
+		if sg.gather.trySend(name, reply{seq: c.seq, res: handler(c.req)}) != nil {
+			return
+		}
+	}
+}
+
+// Close closes plexuses of the ScatterGather. Waiting calls and workers are released.
+func (sg *ScatterGather) Close() {
+	sg.scatter.Close()
+	sg.gather.Close()
+}
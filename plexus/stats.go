@@ -0,0 +1,56 @@
+package plexus
+
+// Stats struct represents a snapshot of a Plexus state and counters.
+type Stats struct {
+	Name      string // Name is a name of the Plexus.
+	Mode      int    // Mode is a state of the Plexus. See MsMr, MsSr, SsMr, SsSr constants.
+	Senders   int    // Senders is a number of simultaneous senders.
+	Receivers int    // Receivers is a number of simultaneous receivers.
+
+	Active bool // Active defines that the Plexus has been used by a sender or a receiver.
+	Closed bool // Closed defines that the Plexus is closed.
+
+	Rounds uint64 // Rounds is a number of completed rounds.
+	Recvs  uint64 // Recvs is a number of Plexus.Recv calls.
+	Sends  uint64 // Sends is a number of Plexus.Send calls.
+
+	WaitingReceivers int // WaitingReceivers is a number of receivers which have at least one blocked call.
+	WaitingSenders   int // WaitingSenders is a number of senders which have at least one blocked call.
+
+	Throttled uint64 // Throttled is a number of Plexus.Send calls which have waited for a rate limit.
+	Rejected  uint64 // Rejected is a number of Plexus.Send calls which have failed with ErrorRateLimited.
+}
+
+// Stats returns a snapshot of the Plexus state and counters.
+func (plx *Plexus) Stats() Stats {
+	plx.lockSnapshot()
+	defer plx.lock.Unlock()
+	var throttled, rejected uint64
+	for _, p := range plx.sendq.pm {
+		throttled += p.m.throttled.Load()
+		rejected += p.m.rejected.Load()
+	}
+	return Stats{
+		Name:             plx.name,
+		Mode:             plx.State(),
+		Senders:          plx.sendn,
+		Receivers:        plx.recvn,
+		Active:           plx.active.Load(),
+		Closed:           plx.closed,
+		Rounds:           plx.rounds,
+		Recvs:            plx.recvs.Load(),
+		Sends:            plx.sends.Load(),
+		WaitingReceivers: plx.recvq.occupancy(),
+		WaitingSenders:   plx.sendq.occupancy(),
+		Throttled:        throttled,
+		Rejected:         rejected,
+	}
+}
+
+// Participants returns snapshots of counters of all senders and receivers of the Plexus. Senders go first, each kind
+// is sorted by names.
+func (plx *Plexus) Participants() []ParticipantStats {
+	plx.lock.RLock()
+	defer plx.lock.RUnlock()
+	return append(plx.sendq.stats(categorySender), plx.recvq.stats(categoryReceiver)...)
+}
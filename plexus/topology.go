@@ -0,0 +1,285 @@
+package plexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// modes maps names of modes used in a Topology description to the mode constants.
+var modes = map[string]int{
+	"MsMr": MsMr,
+	"MsSr": MsSr,
+	"SsMr": SsMr,
+	"SsSr": SsSr,
+}
+
+// Topology struct describes a graph of named plexuses and links between them.
+type Topology struct {
+	Plexuses []PlexusSpec `json:"plexuses"`
+	Links    []LinkSpec   `json:"links"`
+	// Inputs is a set of senders which are fed outside the graph.
+	Inputs []Endpoint `json:"inputs"`
+	// Outputs is a set of receivers which are read outside the graph.
+	Outputs []Endpoint `json:"outputs"`
+}
+
+// PlexusSpec struct describes a single Plexus of a Topology.
+type PlexusSpec struct {
+	Name      string   `json:"name"`
+	Senders   []string `json:"senders"`
+	Receivers []string `json:"receivers"`
+	// Mode is an optional name of an expected mode: MsMr, MsSr, SsMr or SsSr. It is checked against the number of
+	// senders and receivers.
+	Mode       string `json:"mode,omitempty"`
+	Selectable bool   `json:"selectable,omitempty"`
+}
+
+// LinkSpec struct describes a Link between a receiver of a source Plexus and a sender of a destination Plexus.
+type LinkSpec struct {
+	From Endpoint `json:"from"`
+	To   Endpoint `json:"to"`
+}
+
+// Endpoint struct refers to a sender or a receiver (by name) of a Plexus (by name).
+type Endpoint struct {
+	Plexus string `json:"plexus"`
+	Name   string `json:"name"`
+}
+
+func (e Endpoint) String() string {
+	return e.Plexus + "." + e.Name
+}
+
+// ParseTopology reads a Topology from a given JSON document. Unknown fields are not allowed.
+func ParseTopology(r io.Reader) (*Topology, error) {
+	var (
+		dec = json.NewDecoder(r)
+		t   = &Topology{}
+	)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(t); err != nil {
+		return nil, fmt.Errorf("can not parse topology: %w", err)
+	}
+	return t, nil
+}
+
+// Validate checks a Topology is consistent. It reports duplicate and unknown names, names which are used for a sender
+// and a receiver of a plexus both, plexuses without senders or receivers, mismatched modes, dangling senders and
+// receivers, which are neither linked nor declared as inputs or outputs, and cycles of links. All found errors are joined together.
+func (t *Topology) Validate() error {
+	var (
+		errs    []error
+		invalid = func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrorInvalidTopology, fmt.Sprintf(format, args...)))
+		}
+		specs     = make(map[string]PlexusSpec, len(t.Plexuses))
+		senders   = make(map[Endpoint]bool)
+		receivers = make(map[Endpoint]bool)
+	)
+	for _, spec := range t.Plexuses {
+		if spec.Name == "" {
+			invalid("plexus without a name")
+			continue
+		}
+		if _, ok := specs[spec.Name]; ok {
+			invalid("duplicate plexus '%s'", spec.Name)
+			continue
+		}
+		specs[spec.Name] = spec
+		if len(spec.Senders) == 0 {
+			invalid("plexus '%s' has no senders", spec.Name)
+		}
+		if len(spec.Receivers) == 0 {
+			invalid("plexus '%s' has no receivers", spec.Name)
+		}
+		for _, name := range spec.Senders {
+			var ep = Endpoint{Plexus: spec.Name, Name: name}
+			if _, ok := senders[ep]; ok {
+				invalid("duplicate sender '%s'", ep)
+			}
+			senders[ep] = false
+		}
+		for _, name := range spec.Receivers {
+			var ep = Endpoint{Plexus: spec.Name, Name: name}
+			if _, ok := receivers[ep]; ok {
+				invalid("duplicate receiver '%s'", ep)
+			}
+			receivers[ep] = false
+			if slices.Contains(spec.Senders, name) {
+				invalid("name '%s' of plexus '%s' is used for a sender and a receiver", name, spec.Name)
+			}
+		}
+		if spec.Mode != "" && len(spec.Senders) > 0 && len(spec.Receivers) > 0 {
+			mode, ok := modes[spec.Mode]
+			if !ok {
+				invalid("plexus '%s' has unknown mode '%s'", spec.Name, spec.Mode)
+			} else if mode != stateOf(len(spec.Senders), len(spec.Receivers)) {
+				invalid("plexus '%s' does not match mode '%s'", spec.Name, spec.Mode)
+			}
+		}
+	}
+
+	// use marks a given endpoint as used. It reports unknown and reused endpoints.
+	var use = func(set map[Endpoint]bool, ep Endpoint, kind string) bool {
+		used, ok := set[ep]
+		switch {
+		case !ok:
+			invalid("unknown %s '%s'", kind, ep)
+		case used:
+			invalid("%s '%s' is used more than once", kind, ep)
+		default:
+			set[ep] = true
+		}
+		return ok
+	}
+	var edges = make(map[string][]string, len(specs))
+	for _, l := range t.Links {
+		var (
+			okFrom = use(receivers, l.From, "receiver")
+			okTo   = use(senders, l.To, "sender")
+		)
+		if okFrom && okTo {
+			edges[l.From.Plexus] = append(edges[l.From.Plexus], l.To.Plexus)
+		}
+	}
+	for _, ep := range t.Inputs {
+		use(senders, ep, "sender")
+	}
+	for _, ep := range t.Outputs {
+		use(receivers, ep, "receiver")
+	}
+	for _, spec := range t.Plexuses {
+		for _, name := range spec.Senders {
+			if !senders[Endpoint{Plexus: spec.Name, Name: name}] {
+				invalid("dangling sender '%s.%s'", spec.Name, name)
+			}
+		}
+		for _, name := range spec.Receivers {
+			if !receivers[Endpoint{Plexus: spec.Name, Name: name}] {
+				invalid("dangling receiver '%s.%s'", spec.Name, name)
+			}
+		}
+	}
+
+	if cycle := findCycle(t.Plexuses, edges); cycle != nil {
+		invalid("cycle %v", cycle)
+	}
+	return errors.Join(errs...)
+}
+
+// findCycle returns names of plexuses which form a cycle of links. It returns nil if there are no cycles.
+func findCycle(specs []PlexusSpec, edges map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make(map[string]int, len(specs))
+		path  []string
+		visit func(name string) []string
+	)
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, next := range edges[name] {
+			switch state[next] {
+			case visiting:
+				for i, n := range path {
+					if n == next {
+						return append(append([]string{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, spec := range specs {
+		if state[spec.Name] == unvisited {
+			if cycle := visit(spec.Name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Graph struct represents plexuses and links instantiated from a Topology.
+type Graph struct {
+	names    []string
+	plexuses map[string]*Plexus
+	links    []*Link
+}
+
+// Build validates a Topology and instantiates all plexuses and links of it.
+func (t *Topology) Build() (*Graph, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	var g = &Graph{
+		names:    make([]string, 0, len(t.Plexuses)),
+		plexuses: make(map[string]*Plexus, len(t.Plexuses)),
+		links:    make([]*Link, 0, len(t.Links)),
+	}
+	for _, spec := range t.Plexuses {
+		var options = []Option{
+			WithName(spec.Name),
+			WithSenders(spec.Senders...),
+			WithReceivers(spec.Receivers...),
+		}
+		if spec.Selectable {
+			options = append(options, WithSelectableSenders())
+		}
+		plx, err := NewPlexusE(options...)
+		if err != nil {
+			return nil, fmt.Errorf("can not build plexus '%s': %w", spec.Name, err)
+		}
+		g.names = append(g.names, spec.Name)
+		g.plexuses[spec.Name] = plx
+	}
+	for _, l := range t.Links {
+		g.links = append(g.links, Pipe(g.plexuses[l.From.Plexus], l.From.Name, g.plexuses[l.To.Plexus], l.To.Name))
+	}
+	return g, nil
+}
+
+// Plexus returns a Plexus of the Graph by name.
+func (g *Graph) Plexus(name string) (*Plexus, bool) {
+	plx, ok := g.plexuses[name]
+	return plx, ok
+}
+
+// Plexuses returns all plexuses of the Graph in the order of the Topology description.
+func (g *Graph) Plexuses() []*Plexus {
+	var result = make([]*Plexus, 0, len(g.names))
+	for _, name := range g.names {
+		result = append(result, g.plexuses[name])
+	}
+	return result
+}
+
+// Links returns all links of the Graph in the order of the Topology description.
+func (g *Graph) Links() []*Link {
+	return g.links
+}
+
+// Close closes all plexuses of the Graph which are not closed yet.
+func (g *Graph) Close() {
+	for _, name := range g.names {
+		g.plexuses[name].tryClose()
+	}
+}
+
+// Wait blocks till all links of the Graph are stopped and returns their errors joined together.
+func (g *Graph) Wait() error {
+	return WaitLinks(g.links...)
+}
@@ -0,0 +1,177 @@
+package plexus
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	categoryReceiver = "receiver"
+	categoryRound    = "round"
+	categorySender   = "sender"
+)
+
+// traceEvent struct represents an event of the Chrome trace event format.
+// Details: https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type traceEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat,omitempty"`
+	Ph   string         `json:"ph"`
+	Ts   float64        `json:"ts"`
+	Dur  float64        `json:"dur,omitempty"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// traceThread struct identifies a participant of a Plexus in a trace.
+type traceThread struct {
+	plexus string
+	name   string
+}
+
+// Recorder struct collects timings of plexus operations and writes them in the Chrome trace event format.
+// Each Plexus is a process and each participant is a thread of a trace. Recorder collects:
+//   - a wait event for each blocked Plexus.Send and Plexus.Recv call: from enqueue till release;
+//   - a round event for each completed round: from the round start till values are passed to all participants;
+//   - a merge event for each merge of sent values.
+//
+// Recorder is attached to a Plexus with WithRecorder option. One Recorder may be shared by several plexuses.
+type Recorder struct {
+	lock    sync.Mutex
+	origin  time.Time
+	events  []traceEvent
+	pids    map[string]int
+	threads map[traceThread]int
+}
+
+// NewRecorder creates a Recorder object. Timestamps of events are counted from the creation time.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		origin:  time.Now(),
+		pids:    map[string]int{},
+		threads: map[traceThread]int{},
+	}
+}
+
+// Len returns a number of recorded events except metadata events.
+func (rec *Recorder) Len() int {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	var result int
+	for _, e := range rec.events {
+		if e.Ph != "M" {
+			result += 1
+		}
+	}
+	return result
+}
+
+// WriteTo writes all recorded events into a given writer as a JSON object of the Chrome trace event format.
+func (rec *Recorder) WriteTo(w io.Writer) (int64, error) {
+	rec.lock.Lock()
+	var doc = struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{
+		TraceEvents:     append([]traceEvent{}, rec.events...),
+		DisplayTimeUnit: "ms",
+	}
+	rec.lock.Unlock()
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// now returns the current time if the Recorder is defined.
+func (rec *Recorder) now() time.Time {
+	if rec == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// merge records a merge event of a participant.
+func (rec *Recorder) merge(plexus, name string, start time.Time) {
+	if rec == nil {
+		return
+	}
+	rec.add(plexus, name, traceEvent{
+		Name: "merge",
+		Cat:  categoryRound,
+	}, start)
+}
+
+// round records an event of a completed round. A given name is a name of the participant which completes the round.
+func (rec *Recorder) round(plexus, name string, round uint64, start time.Time) {
+	if rec == nil {
+		return
+	}
+	rec.add(plexus, name, traceEvent{
+		Name: "round",
+		Cat:  categoryRound,
+		Args: map[string]any{"round": round},
+	}, start)
+}
+
+// wait records a wait event of a blocked participant.
+func (rec *Recorder) wait(plexus, category, name string, start time.Time) {
+	if rec == nil {
+		return
+	}
+	rec.add(plexus, name, traceEvent{
+		Name: "wait",
+		Cat:  category,
+	}, start)
+}
+
+// add adds a complete event which lasts from a given start time till now.
+func (rec *Recorder) add(plexus, name string, e traceEvent, start time.Time) {
+	var end = time.Now()
+	if plexus == "" {
+		plexus = "plexus"
+	}
+
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+	e.Ph = "X"
+	e.Ts = float64(start.Sub(rec.origin).Nanoseconds()) / 1e3
+	e.Dur = float64(end.Sub(start).Nanoseconds()) / 1e3
+	e.Pid, e.Tid = rec.thread(plexus, name)
+	rec.events = append(rec.events, e)
+}
+
+// thread returns process and thread identifiers of a participant. Metadata events are added for new identifiers.
+func (rec *Recorder) thread(plexus, name string) (int, int) {
+	pid, ok := rec.pids[plexus]
+	if !ok {
+		pid = len(rec.pids) + 1
+		rec.pids[plexus] = pid
+		rec.events = append(rec.events, traceEvent{
+			Name: "process_name",
+			Ph:   "M",
+			Pid:  pid,
+			Args: map[string]any{"name": plexus},
+		})
+	}
+	var key = traceThread{plexus: plexus, name: name}
+	tid, ok := rec.threads[key]
+	if !ok {
+		tid = len(rec.threads) + 1
+		rec.threads[key] = tid
+		rec.events = append(rec.events, traceEvent{
+			Name: "thread_name",
+			Ph:   "M",
+			Pid:  pid,
+			Tid:  tid,
+			Args: map[string]any{"name": name},
+		})
+	}
+	return pid, tid
+}
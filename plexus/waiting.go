@@ -0,0 +1,55 @@
+package plexus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Waiter struct represents blocked calls of a sender or a receiver of a Plexus.
+type Waiter struct {
+	Name   string        // Name is a name of the participant.
+	Kind   string        // Kind is "sender" or "receiver".
+	Queued int           // Queued is a number of blocked calls of the participant.
+	Waited time.Duration // Waited is a waiting time of the oldest blocked call.
+}
+
+func (w Waiter) String() string {
+	return fmt.Sprintf("%s %s queued=%d waited=%s", w.Kind, w.Name, w.Queued, w.Waited)
+}
+
+// Waiting returns all senders and receivers of the Plexus which have blocked calls. Senders go first, each kind is
+// sorted by names.
+func (plx *Plexus) Waiting() []Waiter {
+	plx.lockSnapshot()
+	defer plx.lock.Unlock()
+	var now = time.Now()
+	return append(plx.sendq.waiting(categorySender, now), plx.recvq.waiting(categoryReceiver, now)...)
+}
+
+// String returns a short description of the Plexus: name, mode, closed and active flags and occupancy of queues.
+func (plx *Plexus) String() string {
+	var st = plx.Stats()
+	return fmt.Sprintf("plexus %q mode=%s closed=%t active=%t senders=%d/%d receivers=%d/%d",
+		st.Name, modeName(st.Mode), st.Closed, st.Active,
+		st.WaitingSenders, st.Senders, st.WaitingReceivers, st.Receivers)
+}
+
+// Format implements fmt.Formatter interface. Verbs %s and %v print the Plexus.String result. Verb %+v adds blocked
+// calls of participants returned by Plexus.Waiting.
+func (plx *Plexus) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		var sb strings.Builder
+		sb.WriteString(plx.String())
+		if verb == 'v' && f.Flag('+') {
+			for _, w := range plx.Waiting() {
+				sb.WriteString("\n\t")
+				sb.WriteString(w.String())
+			}
+		}
+		_, _ = f.Write([]byte(sb.String()))
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(*plexus.Plexus=%s)", verb, plx.String())
+	}
+}
@@ -0,0 +1,211 @@
+package plexus
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// windowSender is a name of a sender of an output plexus of a Window.
+const windowSender = "window"
+
+// Clock declares a source of time for a Window. It allows tests to control time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel which delivers the current time after a given duration.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock struct implements a Clock by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// LatePolicy defines how a Window handles a value which arrives after its window is emitted.
+type LatePolicy int
+
+const (
+	// LateDrop drops a late value.
+	LateDrop LatePolicy = iota
+	// LateNext merges a late value into the first window which is not emitted yet.
+	LateNext
+	// LateFail rejects a late value with ErrorLateValue.
+	LateFail
+)
+
+// WindowSpec struct defines windows of a Window.
+type WindowSpec struct {
+	// Size is a duration of a window.
+	Size time.Duration
+	// Slide is a period of windows. Windows are tumbling, if Slide is zero or equals Size. Otherwise, windows are
+	// sliding and Size must be a multiple of Slide.
+	Slide time.Duration
+	// Late is a policy for values which arrive after their window is emitted.
+	Late LatePolicy
+	// Clock is a source of time. The time package is used by default.
+	Clock Clock
+}
+
+// WindowResult struct represents a merged value of a single window.
+type WindowResult struct {
+	Start time.Time // Start is an inclusive start time of the window.
+	End   time.Time // End is an exclusive end time of the window.
+	Value Mergeable // Value is a merged value of all values added during the window.
+}
+
+// Window struct represents an aggregator which merges values added during time windows and emits one merged value
+// per window to receivers. Windows are aligned to multiples of a slide since the Unix epoch. A window without values is
+// not emitted. Results are passed to receivers by a Plexus with a single sender, so each receiver takes each result.
+type Window struct {
+	spec WindowSpec
+	out  *Plexus
+
+	lock      sync.Mutex
+	panes     map[int64]Mergeable // panes is a set of merged values of slides by start times in nanoseconds.
+	watermark time.Time           // watermark is a start time of the first slide of windows which are not emitted.
+	dropped   uint64              // dropped is a number of dropped late values.
+}
+
+// NewWindow creates a Window with a given spec and receivers with given names. It returns an error wrapping
+// ErrorInvalidOptions if the spec or names of receivers are not valid.
+func NewWindow(spec WindowSpec, receivers ...string) (*Window, error) {
+	if spec.Slide == 0 {
+		spec.Slide = spec.Size
+	}
+	if spec.Clock == nil {
+		spec.Clock = realClock{}
+	}
+	if spec.Size <= 0 || spec.Slide <= 0 || spec.Slide > spec.Size || spec.Size%spec.Slide != 0 {
+		return nil, fmt.Errorf("%w: invalid window size %s and slide %s", ErrorInvalidOptions, spec.Size, spec.Slide)
+	}
+	out, err := NewPlexusE(WithName(windowSender), WithSenders(windowSender), WithReceivers(receivers...))
+	if err != nil {
+		return nil, fmt.Errorf("can not create window: %w", err)
+	}
+	var w = &Window{
+		spec:      spec,
+		out:       out,
+		panes:     make(map[int64]Mergeable),
+		watermark: align(spec.Clock.Now(), spec.Slide),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Add merges a given value into the window of the current time of the clock.
+func (w *Window) Add(value Mergeable) error {
+	return w.AddAt(w.spec.Clock.Now(), value)
+}
+
+// AddAt merges a given value into windows of a given time. If all windows of the time are emitted already, then
+// the value is handled by a late policy. It returns an error wrapping ErrorLateValue for the LateFail policy.
+func (w *Window) AddAt(t time.Time, value Mergeable) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if t.Before(w.watermark) {
+		switch w.spec.Late {
+		case LateNext:
+			t = w.watermark
+		case LateFail:
+			return fmt.Errorf("can not add value at %s before %s: %w", t, w.watermark, ErrorLateValue)
+		default:
+			w.dropped += 1
+			return nil
+		}
+	}
+	var key = align(t, w.spec.Slide).UnixNano()
+	if res, ok := w.panes[key]; ok {
+		w.panes[key] = res.Merge(value)
+	} else {
+		w.panes[key] = value
+	}
+	return nil
+}
+
+// Dropped returns a number of dropped late values.
+func (w *Window) Dropped() uint64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.dropped
+}
+
+// Recv returns a result of the next window for a receiver with a given name. See Plexus.Recv for details.
+func (w *Window) Recv(name string) (any, bool) {
+	return w.out.Recv(name)
+}
+
+// Close stops emissions of windows and closes the output Plexus. Values of open windows are discarded.
+func (w *Window) Close() {
+	w.out.Close()
+}
+
+// align returns a given time rounded down to a multiple of a given duration since the Unix epoch.
+func align(t time.Time, d time.Duration) time.Time {
+	var (
+		ns  = t.UnixNano()
+		rem = ns % int64(d)
+	)
+	if rem < 0 {
+		rem += int64(d)
+	}
+	return time.Unix(0, ns-rem)
+}
+
+// run emits windows at the end of each slide till the Window is closed.
+func (w *Window) run() {
+	w.lock.Lock()
+	var end = w.watermark.Add(w.spec.Slide)
+	w.lock.Unlock()
+	for {
+		select {
+		case <-w.spec.Clock.After(end.Sub(w.spec.Clock.Now())):
+		case <-w.out.Done():
+			return
+		}
+		if res, ok := w.emit(end); ok && w.out.trySend(windowSender, res) != nil {
+			return
+		}
+		end = end.Add(w.spec.Slide)
+	}
+}
+
+// emit merges values of the window which ends at a given time. It returns FALSE if there are no values in the window.
+// Slides which do not belong to following windows are discarded.
+func (w *Window) emit(end time.Time) (WindowResult, bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var (
+		start = end.Add(-w.spec.Size)
+		res   Mergeable
+	)
+	for t := start; t.Before(end); t = t.Add(w.spec.Slide) {
+		var key = t.UnixNano()
+		v, ok := w.panes[key]
+		if !ok {
+			continue
+		}
+		if res == nil {
+			res = v
+		} else {
+			res = res.Merge(v)
+		}
+	}
+	var first = end.Add(w.spec.Slide - w.spec.Size)
+	for key := range w.panes {
+		if key < first.UnixNano() {
+			delete(w.panes, key)
+		}
+	}
+	w.watermark = first
+	if res == nil {
+		return WindowResult{}, false
+	}
+	return WindowResult{Start: start, End: end, Value: res}, true
+}